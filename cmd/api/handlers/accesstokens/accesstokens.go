@@ -0,0 +1,113 @@
+package accesstokens
+
+import (
+	"ShoppingList-Backend/internal/pkg/accesstoken"
+	"ShoppingList-Backend/internal/pkg/common"
+	"ShoppingList-Backend/pkg/application"
+	"ShoppingList-Backend/pkg/middleware"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// GetAccessTokens func gets the personal access tokens of the user
+// @Description Get the personal access tokens of the user. The tokens themselves are only shown when they are created
+// @Summary Get personal access tokens
+// @Tags access tokens
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Success 200 {object} common.Response{data=[]accesstoken.AccessToken}
+// @Failure 500 {object} server.HTTPError
+// @Failure 403 {object} server.HTTPError
+// @Router /api/v1/me/tokens [get]
+func GetAccessTokens(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		appUser := middleware.UserFromContext(r.Context())
+
+		accessTokens, cErr := app.Controllers.AccessToken.GetAccessTokens(appUser)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: accessTokens,
+		})
+	}
+}
+
+// CreateAccessToken func Create personal access token
+// @Description Create a personal access token, for scripts and integrations. Use it as a bearer token.
+// @Description Tokens with scopes can only use the routes their scopes allow, tokens without have the same access as the user.
+// @Description The token is only returned in this response
+// @Summary Create personal access token
+// @Tags access tokens
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param accessToken body accesstoken.AddAccessToken true "Add access token"
+// @Success 201 {object} common.Response{data=accesstoken.CreatedAccessToken}
+// @Failure 500 {object} server.HTTPError
+// @Failure 403 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/me/tokens [post]
+func CreateAccessToken(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		addAccessToken := &accesstoken.AddAccessToken{}
+		if err := app.Srv.Decode(w, r, addAccessToken); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		createdAccessToken, cErr := app.Controllers.AccessToken.CreateAccessToken(appUser, addAccessToken)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusCreated, common.Response{
+			Data: createdAccessToken,
+		})
+	}
+}
+
+// DeleteAccessToken func Revoke personal access token
+// @Description Revoke a personal access token. It can no longer be used
+// @Summary Revoke personal access token
+// @Tags access tokens
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Access token ID"
+// @Success 204 {string} status "ok"
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 403 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/me/tokens/{id} [delete]
+func DeleteAccessToken(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse access token id %v: %w", idStr, err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		_, cErr := app.Controllers.AccessToken.DeleteAccessToken(appUser, id)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusNoContent, nil)
+	}
+}
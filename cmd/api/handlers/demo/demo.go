@@ -0,0 +1,33 @@
+package demo
+
+import (
+	"ShoppingList-Backend/internal/pkg/common"
+	"ShoppingList-Backend/pkg/application"
+	"net/http"
+)
+
+// ProvisionDemoUser func Create demo user
+// @Description Create a demo user with sample items and lists, and return the credentials to log in with.
+// @Description The data of demo users is reset every night, and demo users are deleted after a day
+// @Summary Create demo user
+// @Tags demo
+// @Accept json
+// @Produce json
+// @Success 201 {object} common.Response{data=demo.DemoUser}
+// @Failure 500 {object} server.HTTPError
+// @Failure 503 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Router /api/v1/demo [post]
+func ProvisionDemoUser(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		demoUser, cErr := app.Controllers.Demo.ProvisionDemoUser(r.Context())
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusCreated, common.Response{
+			Data: demoUser,
+		})
+	}
+}
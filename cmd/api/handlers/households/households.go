@@ -0,0 +1,192 @@
+package households
+
+import (
+	"ShoppingList-Backend/internal/pkg/common"
+	"ShoppingList-Backend/internal/pkg/household"
+	"ShoppingList-Backend/pkg/application"
+	"ShoppingList-Backend/pkg/middleware"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// GetHouseholds func gets the households of the user
+// @Description Get all households the user is a member of. Send the ID of one in the X-Household-ID header to act on its items, lists and pantry. Without the header, the personal household is used
+// @Summary get households
+// @Tags households
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Success 200 {object} common.Response{data=[]household.Household}
+// @Failure 500 {object} server.HTTPError
+// @Router /api/v1/households [get]
+func GetHouseholds(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		appUser := middleware.UserFromContext(r.Context())
+
+		households, err := app.Controllers.Household.GetHouseholds(appUser)
+		if err != nil {
+			app.Srv.RespondError(w, r, err.StatusCode, err.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: households,
+		})
+	}
+}
+
+// CreateHousehold func Create household
+// @Description Create a household that can be shared with other users. The creator becomes its owner
+// @Summary Create household
+// @Tags households
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param household body household.AddHousehold true "Add household"
+// @Success 200 {object} common.Response{data=household.Household}
+// @Failure 500 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/households [post]
+func CreateHousehold(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		addHousehold := &household.AddHousehold{}
+		if err := app.Srv.Decode(w, r, addHousehold); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		createdHousehold, cErr := app.Controllers.Household.CreateHousehold(appUser, addHousehold)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: createdHousehold,
+		})
+	}
+}
+
+// GetHouseholdMembers func gets the members of a household
+// @Description Get the members of a household and their roles
+// @Summary get household members
+// @Tags households
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Household ID"
+// @Success 200 {object} common.Response{data=[]household.Member}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/households/{id}/members [get]
+func GetHouseholdMembers(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse household id %v: %w", idStr, err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		members, cErr := app.Controllers.Household.GetMembers(appUser, id)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: members,
+		})
+	}
+}
+
+// SetHouseholdMember func Add or update household member
+// @Description Add a user to a household, or change their role. Only owners can manage members, and personal households cannot be shared
+// @Summary Add or update household member
+// @Tags households
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Household ID"
+// @Param member body household.AddMember true "Member"
+// @Success 200 {object} common.Response{data=household.Member}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 403 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/households/{id}/members [put]
+func SetHouseholdMember(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse household id %v: %w", idStr, err))
+			return
+		}
+
+		addMember := &household.AddMember{}
+		if err := app.Srv.Decode(w, r, addMember); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		member, cErr := app.Controllers.Household.SetMember(appUser, id, addMember)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: member,
+		})
+	}
+}
+
+// RemoveHouseholdMember func Remove household member
+// @Description Remove a user from a household. Owners can remove other members, and members can remove themselves to leave the household
+// @Summary Remove household member
+// @Tags households
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Household ID"
+// @Param userId path string true "User ID"
+// @Success 204 {string} status "ok"
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 403 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/households/{id}/members/{userId} [delete]
+func RemoveHouseholdMember(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse household id %v: %w", idStr, err))
+			return
+		}
+		userID := params["userId"]
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		if cErr := app.Controllers.Household.RemoveMember(appUser, id, userID); cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusNoContent, nil)
+	}
+}
@@ -13,7 +13,7 @@ import (
 )
 
 // GetItems func gets all items for user
-// @Description Get all items for user
+// @Description Get all items in the catalog of the household, which is shared by all its members
 // @Summary get all items for user
 // @Tags items
 // @Security ApiKeyAuth
@@ -39,6 +39,43 @@ func GetItems(app *application.Application) http.HandlerFunc {
 	}
 }
 
+// GetItemCreator func gets who created an item
+// @Description Get the user that added the item to the household's catalog, and when
+// @Summary Get creator of item
+// @Tags items
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Item ID"
+// @Success 200 {object} common.Response{data=item.ItemCreator}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/items/{id}/creator [get]
+func GetItemCreator(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse item id %v: %w", idStr, err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		itemCreator, cErr := app.Controllers.Item.GetItemCreator(appUser, id)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: itemCreator,
+		})
+	}
+}
+
 // CreateItem func Create new item
 // @Description Create new item
 // @Summary Create new item
@@ -106,7 +143,7 @@ func UpdateItem(app *application.Application) http.HandlerFunc {
 		}
 
 		updatedItem, errr := app.Controllers.Item.UpdateItem(appUser, id, addItem)
-		if err != nil {
+		if errr != nil {
 			app.Srv.RespondError(w, r, errr.StatusCode, errr.Err)
 			return
 		}
@@ -151,3 +188,47 @@ func DeleteItem(app *application.Application) http.HandlerFunc {
 	}
 
 }
+
+// MergeItems func Merge duplicate items into item
+// @Description Merge duplicate items into item. List items, purchases, prices, recipe ingredients and recurring items referencing the merged items are moved to the item, and their pantry stock is added to it
+// @Summary Merge duplicate items into item
+// @Tags items
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Item ID"
+// @Param items body item.MergeItems true "Items to merge"
+// @Success 200 {object} common.Response{data=item.Item}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/items/{id}/merge [post]
+func MergeItems(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse item id %v: %w", idStr, err))
+			return
+		}
+
+		mergeItems := &item.MergeItems{}
+		if err := app.Srv.Decode(w, r, mergeItems); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		mergedItem, cErr := app.Controllers.Item.MergeItems(appUser, id, mergeItems)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: mergedItem,
+		})
+	}
+}
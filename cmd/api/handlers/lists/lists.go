@@ -2,6 +2,7 @@ package lists
 
 import (
 	"ShoppingList-Backend/internal/pkg/common"
+	"ShoppingList-Backend/internal/pkg/item"
 	"ShoppingList-Backend/internal/pkg/list"
 	"ShoppingList-Backend/pkg/application"
 	"ShoppingList-Backend/pkg/middleware"
@@ -38,6 +39,45 @@ func GetLists(app *application.Application) http.HandlerFunc {
 	}
 }
 
+// GetList func Get list
+// @Description Get list. With include=costs, the estimated cost of the uncrossed items and the amount spent on the crossed items are included, based on the latest price of each item
+// @Summary Get list
+// @Tags lists
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID"
+// @Param include query string false "Set to costs to include cost estimates"
+// @Success 200 {object} common.Response{data=price.ListWithCosts}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/lists/{id} [get]
+func GetList(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse id %v: %w", idStr, err))
+			return
+		}
+		includeCosts := r.URL.Query().Get("include") == "costs"
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		foundList, cErr := app.Controllers.Price.GetListWithCosts(appUser, id, includeCosts)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: foundList,
+		})
+	}
+}
+
 // GetDefaultList func Get the user's default list
 // @Description Get the user's default list
 // @Summary Get the user's default list
@@ -186,6 +226,56 @@ func SetDefaultList(app *application.Application) http.HandlerFunc {
 	}
 }
 
+// AddBarcodeToList func Add item to list by barcode
+// @Description Add the item with the barcode (EAN-8, EAN-13 or UPC-A) to the list. If no item in the catalog has the barcode, the product is looked up in the product database and added to the catalog
+// @Summary Add item to list by barcode
+// @Tags lists
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID"
+// @Param barcode body item.AddBarcode true "Barcode"
+// @Success 200 {object} common.Response{data=list.ListItem}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/lists/{id}/items/barcode [post]
+func AddBarcodeToList(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		listIdStr := params["id"]
+		listId, err := uuid.Parse(listIdStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse list id %v: %w", listIdStr, err))
+			return
+		}
+
+		addBarcode := &item.AddBarcode{}
+		if err := app.Srv.Decode(w, r, addBarcode); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+
+		user := middleware.UserFromContext(r.Context())
+
+		foundItem, cErr := app.Controllers.Item.ResolveBarcode(user, addBarcode)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		listItem, cErr := app.Controllers.List.AddItemToList(user, listId, foundItem.ID)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: listItem,
+		})
+	}
+}
+
 // AddItemToList func Add item to list
 // @Description Add item to list
 // @Summary Add item to list
@@ -414,3 +504,202 @@ func ClearCrossedListItems(app *application.Application) http.HandlerFunc {
 		app.Srv.Respond(w, r, http.StatusNoContent, nil)
 	}
 }
+
+// GetListSuggestions func Get "buy again" suggestions for list
+// @Description Get catalog items that are probably running out, based on how often they have been bought, and which are not already on the list
+// @Summary Get "buy again" suggestions for list
+// @Tags lists
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID"
+// @Success 200 {object} common.Response{data=[]suggestion.Suggestion}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/lists/{id}/suggestions [get]
+func GetListSuggestions(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse id %v: %w", idStr, err))
+			return
+		}
+
+		user := middleware.UserFromContext(r.Context())
+
+		suggestions, cErr := app.Controllers.Suggestion.GetListSuggestions(user, id)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: suggestions,
+		})
+	}
+}
+
+// SetListBudget func Set the budget of list
+// @Description Set the budget of a list. A LIST_BUDGET_EXCEEDED event is sent when the estimated cost of the list items goes above it
+// @Summary Set the budget of list
+// @Tags lists
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID"
+// @Param budget body list.Budget true "Budget"
+// @Success 200 {object} common.Response{data=list.List}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/lists/{id}/budget [put]
+func SetListBudget(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse id %v: %w", idStr, err))
+			return
+		}
+
+		budget := &list.Budget{}
+		if err := app.Srv.Decode(w, r, budget); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+
+		user := middleware.UserFromContext(r.Context())
+
+		updatedList, cErr := app.Controllers.List.SetBudget(user, id, budget)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: updatedList,
+		})
+	}
+}
+
+// DeleteListBudget func Remove the budget of list
+// @Description Remove the budget of a list
+// @Summary Remove the budget of list
+// @Tags lists
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID"
+// @Success 200 {object} common.Response{data=list.List}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/lists/{id}/budget [delete]
+func DeleteListBudget(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse id %v: %w", idStr, err))
+			return
+		}
+
+		user := middleware.UserFromContext(r.Context())
+
+		updatedList, cErr := app.Controllers.List.SetBudget(user, id, nil)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: updatedList,
+		})
+	}
+}
+
+// SetListAutoClear func Set auto-clearing of list
+// @Description Clear crossed items from a list automatically, a number of hours after they were crossed. The items are recorded as purchased, like when crossed items are cleared by hand
+// @Summary Set auto-clearing of list
+// @Tags lists
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID"
+// @Param autoClear body list.AutoClear true "Auto-clear"
+// @Success 200 {object} common.Response{data=list.List}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/lists/{id}/auto-clear [put]
+func SetListAutoClear(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse id %v: %w", idStr, err))
+			return
+		}
+
+		autoClear := &list.AutoClear{}
+		if err := app.Srv.Decode(w, r, autoClear); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+
+		user := middleware.UserFromContext(r.Context())
+
+		updatedList, cErr := app.Controllers.List.SetAutoClear(user, id, autoClear)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: updatedList,
+		})
+	}
+}
+
+// DeleteListAutoClear func Stop auto-clearing of list
+// @Description Stop clearing crossed items from a list automatically
+// @Summary Stop auto-clearing of list
+// @Tags lists
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID"
+// @Success 200 {object} common.Response{data=list.List}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/lists/{id}/auto-clear [delete]
+func DeleteListAutoClear(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse id %v: %w", idStr, err))
+			return
+		}
+
+		user := middleware.UserFromContext(r.Context())
+
+		updatedList, cErr := app.Controllers.List.SetAutoClear(user, id, nil)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: updatedList,
+		})
+	}
+}
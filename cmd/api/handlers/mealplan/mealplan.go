@@ -0,0 +1,146 @@
+package mealplan
+
+import (
+	"ShoppingList-Backend/internal/pkg/common"
+	"ShoppingList-Backend/internal/pkg/mealplan"
+	"ShoppingList-Backend/pkg/application"
+	"ShoppingList-Backend/pkg/middleware"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// GetMealPlan func Get meal plan entries in date range
+// @Description Get meal plan entries in date range, with their recipes
+// @Summary Get meal plan entries in date range
+// @Tags mealplan
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param from query string true "From date (inclusive), YYYY-MM-DD"
+// @Param to query string true "To date (inclusive), YYYY-MM-DD"
+// @Success 200 {object} common.Response{data=[]mealplan.Entry}
+// @Failure 500 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/mealplan [get]
+func GetMealPlan(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		appUser := middleware.UserFromContext(r.Context())
+
+		entries, err := app.Controllers.MealPlan.GetEntries(appUser, query.Get("from"), query.Get("to"))
+		if err != nil {
+			app.Srv.RespondError(w, r, err.StatusCode, err.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: entries,
+		})
+	}
+}
+
+// CreateMealPlanEntry func Add recipe to meal plan
+// @Description Add recipe to meal plan on a day
+// @Summary Add recipe to meal plan
+// @Tags mealplan
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param entry body mealplan.AddEntry true "Add meal plan entry"
+// @Success 200 {object} common.Response{data=mealplan.Entry}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/mealplan [post]
+func CreateMealPlanEntry(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		addEntry := &mealplan.AddEntry{}
+		if err := app.Srv.Decode(w, r, addEntry); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+		appUser := middleware.UserFromContext(r.Context())
+
+		createdEntry, err := app.Controllers.MealPlan.CreateEntry(appUser, addEntry)
+		if err != nil {
+			app.Srv.RespondError(w, r, err.StatusCode, err.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: createdEntry,
+		})
+	}
+}
+
+// DeleteMealPlanEntry func Remove recipe from meal plan
+// @Description Remove recipe from meal plan
+// @Summary Remove recipe from meal plan
+// @Tags mealplan
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Meal plan entry ID"
+// @Success 204 {string} status "ok"
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/mealplan/{id} [delete]
+func DeleteMealPlanEntry(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse meal plan entry id %v: %w", idStr, err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		if cErr := app.Controllers.MealPlan.DeleteEntry(appUser, id); cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusNoContent, nil)
+	}
+}
+
+// GenerateShoppingList func Generate shopping list from meal plan
+// @Description Add the ingredients of all recipes planned in the date range to a list, or a new list if no list ID is given.
+// @Description Quantities are aggregated per item, and what is already on the list is subtracted
+// @Summary Generate shopping list from meal plan
+// @Tags mealplan
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param generate body mealplan.GenerateShoppingList true "Date range and target list"
+// @Success 200 {object} common.Response{data=list.List}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/mealplan/shopping-list [post]
+func GenerateShoppingList(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generate := &mealplan.GenerateShoppingList{}
+		if err := app.Srv.Decode(w, r, generate); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+		appUser := middleware.UserFromContext(r.Context())
+
+		generatedList, err := app.Controllers.MealPlan.GenerateShoppingList(appUser, generate)
+		if err != nil {
+			app.Srv.RespondError(w, r, err.StatusCode, err.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: generatedList,
+		})
+	}
+}
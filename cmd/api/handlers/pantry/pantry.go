@@ -0,0 +1,127 @@
+package pantry
+
+import (
+	"ShoppingList-Backend/internal/pkg/common"
+	"ShoppingList-Backend/internal/pkg/pantry"
+	"ShoppingList-Backend/pkg/application"
+	"ShoppingList-Backend/pkg/middleware"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// GetPantryItems func gets the pantry of the user
+// @Description Get the current stock of all items in the pantry
+// @Summary get pantry
+// @Tags pantry
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Success 200 {object} common.Response{data=[]pantry.PantryItem}
+// @Failure 500 {object} server.HTTPError
+// @Router /api/v1/pantry [get]
+func GetPantryItems(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		appUser := middleware.UserFromContext(r.Context())
+
+		pantryItems, err := app.Controllers.Pantry.GetPantryItems(appUser)
+		if err != nil {
+			app.Srv.RespondError(w, r, err.StatusCode, err.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: pantryItems,
+		})
+	}
+}
+
+// UpdatePantryItem func Update pantry item
+// @Description Set the stock and minimum quantity of an item. When the stock falls below the minimum quantity, the item is added to the default list
+// @Summary Update pantry item
+// @Tags pantry
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param itemId path string true "Item ID"
+// @Param pantryItem body pantry.UpdatePantryItem true "Update pantry item"
+// @Success 200 {object} common.Response{data=pantry.PantryItem}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/pantry/{itemId} [put]
+func UpdatePantryItem(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		itemIdStr := params["itemId"]
+		itemId, err := uuid.Parse(itemIdStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse item id %v: %w", itemIdStr, err))
+			return
+		}
+
+		updatePantryItem := &pantry.UpdatePantryItem{}
+		if err := app.Srv.Decode(w, r, updatePantryItem); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		updatedPantryItem, cErr := app.Controllers.Pantry.UpdatePantryItem(appUser, itemId, updatePantryItem)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: updatedPantryItem,
+		})
+	}
+}
+
+// ConsumePantryItem func Consume pantry item
+// @Description Decrement the stock of an item. When the stock falls below the minimum quantity, the item is added to the default list
+// @Summary Consume pantry item
+// @Tags pantry
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param itemId path string true "Item ID"
+// @Param consume body pantry.ConsumePantryItem true "Consumed quantity"
+// @Success 200 {object} common.Response{data=pantry.PantryItem}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/pantry/{itemId}/consume [post]
+func ConsumePantryItem(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		itemIdStr := params["itemId"]
+		itemId, err := uuid.Parse(itemIdStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse item id %v: %w", itemIdStr, err))
+			return
+		}
+
+		consumePantryItem := &pantry.ConsumePantryItem{}
+		if err := app.Srv.Decode(w, r, consumePantryItem); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		updatedPantryItem, cErr := app.Controllers.Pantry.ConsumeItem(appUser, itemId, consumePantryItem)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: updatedPantryItem,
+		})
+	}
+}
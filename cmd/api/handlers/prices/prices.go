@@ -0,0 +1,136 @@
+package prices
+
+import (
+	"ShoppingList-Backend/internal/pkg/common"
+	"ShoppingList-Backend/internal/pkg/price"
+	"ShoppingList-Backend/pkg/application"
+	"ShoppingList-Backend/pkg/middleware"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// GetPrices func Get price history of item
+// @Description Get the recorded prices of an item, newest first
+// @Summary Get price history of item
+// @Tags prices
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Item ID"
+// @Param store query string false "Only include prices from this store"
+// @Success 200 {object} common.Response{data=[]price.Price}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/items/{id}/prices [get]
+func GetPrices(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse item id %v: %w", idStr, err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		prices, cErr := app.Controllers.Price.GetPrices(appUser, id, r.URL.Query().Get("store"))
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: prices,
+		})
+	}
+}
+
+// CreatePrice func Record price of item
+// @Description Record the price of an item, optionally at a store and on a date. The amount is in minor units of the currency
+// @Summary Record price of item
+// @Tags prices
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Item ID"
+// @Param price body price.AddPrice true "Add price"
+// @Success 200 {object} common.Response{data=price.Price}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/items/{id}/prices [post]
+func CreatePrice(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse item id %v: %w", idStr, err))
+			return
+		}
+
+		addPrice := &price.AddPrice{}
+		if err := app.Srv.Decode(w, r, addPrice); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		createdPrice, cErr := app.Controllers.Price.CreatePrice(appUser, id, addPrice)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: createdPrice,
+		})
+	}
+}
+
+// DeletePrice func Delete price
+// @Description Delete a recorded price of an item
+// @Summary Delete price
+// @Tags prices
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Item ID"
+// @Param priceId path string true "Price ID"
+// @Success 204 {string} status "ok"
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/items/{id}/prices/{priceId} [delete]
+func DeletePrice(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse item id %v: %w", idStr, err))
+			return
+		}
+		priceIdStr := params["priceId"]
+		priceId, err := uuid.Parse(priceIdStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse price id %v: %w", priceIdStr, err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		if cErr := app.Controllers.Price.DeletePrice(appUser, id, priceId); cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusNoContent, nil)
+	}
+}
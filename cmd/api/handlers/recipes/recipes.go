@@ -0,0 +1,232 @@
+package recipes
+
+import (
+	"ShoppingList-Backend/internal/pkg/common"
+	"ShoppingList-Backend/internal/pkg/recipe"
+	"ShoppingList-Backend/pkg/application"
+	"ShoppingList-Backend/pkg/middleware"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// GetRecipes func gets all recipes for user
+// @Description Get all recipes for user
+// @Summary get all recipes for user
+// @Tags recipes
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Success 200 {object} common.Response{data=[]recipe.Recipe}
+// @Failure 500 {object} server.HTTPError
+// @Router /api/v1/recipes [get]
+func GetRecipes(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		appUser := middleware.UserFromContext(r.Context())
+
+		recipes, err := app.Controllers.Recipe.GetRecipes(appUser)
+		if err != nil {
+			app.Srv.RespondError(w, r, err.StatusCode, err.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: recipes,
+		})
+	}
+}
+
+// GetRecipe func gets recipe
+// @Description Get recipe
+// @Summary Get recipe
+// @Tags recipes
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Success 200 {object} common.Response{data=recipe.Recipe}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/recipes/{id} [get]
+func GetRecipe(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse recipe id %v: %w", idStr, err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		foundRecipe, cErr := app.Controllers.Recipe.GetRecipe(appUser, id)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: foundRecipe,
+		})
+	}
+}
+
+// CreateRecipe func Create new recipe
+// @Description Create new recipe
+// @Summary Create new recipe
+// @Tags recipes
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param recipe body recipe.AddRecipe true "Add recipe"
+// @Success 200 {object} common.Response{data=recipe.Recipe}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/recipes [post]
+func CreateRecipe(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		addRecipe := &recipe.AddRecipe{}
+		if err := app.Srv.Decode(w, r, addRecipe); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+		appUser := middleware.UserFromContext(r.Context())
+
+		createdRecipe, err := app.Controllers.Recipe.CreateRecipe(appUser, addRecipe)
+		if err != nil {
+			app.Srv.RespondError(w, r, err.StatusCode, err.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: createdRecipe,
+		})
+	}
+}
+
+// UpdateRecipe func Update recipe
+// @Description Update recipe. The ingredients replace the existing ingredients
+// @Summary Update recipe
+// @Tags recipes
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param recipe body recipe.AddRecipe true "Update recipe"
+// @Success 200 {object} common.Response{data=recipe.Recipe}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/recipes/{id} [put]
+func UpdateRecipe(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse recipe id %v: %w", idStr, err))
+			return
+		}
+
+		updateRecipe := &recipe.AddRecipe{}
+		if err := app.Srv.Decode(w, r, updateRecipe); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		updatedRecipe, cErr := app.Controllers.Recipe.UpdateRecipe(appUser, id, updateRecipe)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: updatedRecipe,
+		})
+	}
+}
+
+// DeleteRecipe func Delete recipe
+// @Description Delete recipe
+// @Summary Delete recipe
+// @Tags recipes
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Success 204 {string} status "ok"
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/recipes/{id} [delete]
+func DeleteRecipe(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse recipe id %v: %w", idStr, err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		if cErr := app.Controllers.Recipe.DeleteRecipe(appUser, id); cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusNoContent, nil)
+	}
+}
+
+// AddRecipeToList func Add recipe ingredients to list
+// @Description Add the ingredients of the recipe to a list, scaled to the given servings. Quantities are merged into uncrossed list items of the same item and unit
+// @Summary Add recipe ingredients to list
+// @Tags recipes
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param list body recipe.AddRecipeToList true "Target list"
+// @Success 200 {object} common.Response{data=list.List}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/recipes/{id}/list [post]
+func AddRecipeToList(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse recipe id %v: %w", idStr, err))
+			return
+		}
+
+		addRecipeToList := &recipe.AddRecipeToList{}
+		if err := app.Srv.Decode(w, r, addRecipeToList); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		updatedList, cErr := app.Controllers.Recipe.AddRecipeToList(appUser, id, addRecipeToList)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: updatedList,
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package recurring
+
+import (
+	"ShoppingList-Backend/internal/pkg/common"
+	"ShoppingList-Backend/internal/pkg/recurring"
+	"ShoppingList-Backend/pkg/application"
+	"ShoppingList-Backend/pkg/middleware"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// GetRecurringItems func gets all recurring items for user
+// @Description Get all recurring items for user
+// @Summary get all recurring items for user
+// @Tags recurring-items
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Success 200 {object} common.Response{data=[]recurring.RecurringItem}
+// @Failure 500 {object} server.HTTPError
+// @Router /api/v1/recurring-items [get]
+func GetRecurringItems(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		appUser := middleware.UserFromContext(r.Context())
+
+		recurringItems, err := app.Controllers.Recurring.GetRecurringItems(appUser)
+		if err != nil {
+			app.Srv.RespondError(w, r, err.StatusCode, err.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: recurringItems,
+		})
+	}
+}
+
+// CreateRecurringItem func Create new recurring item
+// @Description Create a rule that adds an item to a list on a schedule. Either cron or intervalDays must be set
+// @Summary Create new recurring item
+// @Tags recurring-items
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param recurringItem body recurring.AddRecurringItem true "Add recurring item"
+// @Success 200 {object} common.Response{data=recurring.RecurringItem}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/recurring-items [post]
+func CreateRecurringItem(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		addRecurringItem := &recurring.AddRecurringItem{}
+		if err := app.Srv.Decode(w, r, addRecurringItem); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+		appUser := middleware.UserFromContext(r.Context())
+
+		createdRecurringItem, err := app.Controllers.Recurring.CreateRecurringItem(appUser, addRecurringItem)
+		if err != nil {
+			app.Srv.RespondError(w, r, err.StatusCode, err.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: createdRecurringItem,
+		})
+	}
+}
+
+// UpdateRecurringItem func Update recurring item
+// @Description Update recurring item. The next run is rescheduled from now
+// @Summary Update recurring item
+// @Tags recurring-items
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Recurring item ID"
+// @Param recurringItem body recurring.AddRecurringItem true "Update recurring item"
+// @Success 200 {object} common.Response{data=recurring.RecurringItem}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/recurring-items/{id} [put]
+func UpdateRecurringItem(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse recurring item id %v: %w", idStr, err))
+			return
+		}
+
+		updateRecurringItem := &recurring.AddRecurringItem{}
+		if err := app.Srv.Decode(w, r, updateRecurringItem); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		updatedRecurringItem, cErr := app.Controllers.Recurring.UpdateRecurringItem(appUser, id, updateRecurringItem)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: updatedRecurringItem,
+		})
+	}
+}
+
+// DeleteRecurringItem func Delete recurring item
+// @Description Delete recurring item
+// @Summary Delete recurring item
+// @Tags recurring-items
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Recurring item ID"
+// @Success 204 {string} status "ok"
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/recurring-items/{id} [delete]
+func DeleteRecurringItem(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		idStr := params["id"]
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse recurring item id %v: %w", idStr, err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		if cErr := app.Controllers.Recurring.DeleteRecurringItem(appUser, id); cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusNoContent, nil)
+	}
+}
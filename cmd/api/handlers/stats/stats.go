@@ -0,0 +1,80 @@
+package stats
+
+import (
+	"ShoppingList-Backend/internal/pkg/common"
+	"ShoppingList-Backend/internal/pkg/purchase"
+	"ShoppingList-Backend/pkg/application"
+	"ShoppingList-Backend/pkg/middleware"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// GetItemStats func Get purchase statistics per item
+// @Description Get purchase count, average interval between purchases and last purchase date per item
+// @Summary Get purchase statistics per item
+// @Tags stats
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param from query string false "Only include purchases from this date (YYYY-MM-DD or RFC3339)"
+// @Param to query string false "Only include purchases before this date (YYYY-MM-DD or RFC3339)"
+// @Param listId query string false "Only include purchases from this list"
+// @Success 200 {object} common.Response{data=[]purchase.ItemStats}
+// @Failure 500 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/stats/items [get]
+func GetItemStats(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		filter := purchase.StatsFilter{}
+
+		if fromStr := query.Get("from"); fromStr != "" {
+			from, err := parseTime(fromStr)
+			if err != nil {
+				app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse from %v: %w", fromStr, err))
+				return
+			}
+			filter.From = &from
+		}
+
+		if toStr := query.Get("to"); toStr != "" {
+			to, err := parseTime(toStr)
+			if err != nil {
+				app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse to %v: %w", toStr, err))
+				return
+			}
+			filter.To = &to
+		}
+
+		if listIdStr := query.Get("listId"); listIdStr != "" {
+			listId, err := uuid.Parse(listIdStr)
+			if err != nil {
+				app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse list id %v: %w", listIdStr, err))
+				return
+			}
+			filter.ListID = &listId
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		stats, cErr := app.Controllers.Purchase.GetItemStats(appUser, filter)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: stats,
+		})
+	}
+}
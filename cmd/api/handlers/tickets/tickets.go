@@ -0,0 +1,42 @@
+package tickets
+
+import (
+	"ShoppingList-Backend/internal/pkg/common"
+	"ShoppingList-Backend/internal/pkg/ticket"
+	"ShoppingList-Backend/pkg/application"
+	"ShoppingList-Backend/pkg/middleware"
+	"net/http"
+)
+
+// CreateTicket func Create connection ticket
+// @Description Create a ticket to open a socket.io connection with, in the ticket query parameter, for clients that cannot send an Authorization header.
+// @Description It can be used once, within 30 seconds
+// @Summary Create connection ticket
+// @Tags tickets
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Success 201 {object} common.Response{data=ticket.Ticket}
+// @Failure 500 {object} server.HTTPError
+// @Router /api/v1/tickets [post]
+func CreateTicket(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		appUser := middleware.UserFromContext(r.Context())
+		grants := middleware.GrantsFromContext(r.Context())
+
+		createdTicket, cErr := app.Controllers.Ticket.CreateTicket(ticket.Holder{
+			UserID:              appUser.ID,
+			Scopes:              grants.Scopes,
+			Roles:               grants.Roles,
+			PersonalAccessToken: grants.PersonalAccessToken,
+		})
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusCreated, common.Response{
+			Data: createdTicket,
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package users
+
+import (
+	"ShoppingList-Backend/internal/pkg/common"
+	"ShoppingList-Backend/internal/pkg/user"
+	"ShoppingList-Backend/pkg/application"
+	"ShoppingList-Backend/pkg/middleware"
+	"fmt"
+	"net/http"
+)
+
+// GetMe func gets the profile of the user
+// @Description Get the profile of the user. Name, email and preferred username are kept up to date from the claims of the JWT
+// @Summary Get profile
+// @Tags users
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Success 200 {object} common.Response{data=user.User}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Router /api/v1/me [get]
+func GetMe(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		appUser := middleware.UserFromContext(r.Context())
+
+		foundUser, cErr := app.Controllers.User.GetUser(appUser)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: foundUser,
+		})
+	}
+}
+
+// UpdateMe func Update profile
+// @Description Update the display name and locale of the user. Fields that are not set are left unchanged
+// @Summary Update profile
+// @Tags users
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param user body user.UpdateUser true "Update user"
+// @Success 200 {object} common.Response{data=user.User}
+// @Failure 500 {object} server.HTTPError
+// @Failure 404 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/me [patch]
+func UpdateMe(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		updateUser := &user.UpdateUser{}
+		if err := app.Srv.Decode(w, r, updateUser); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		updatedUser, cErr := app.Controllers.User.UpdateUser(appUser, updateUser)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: updatedUser,
+		})
+	}
+}
+
+// GetPreferences func gets the preferences of the user
+// @Description Get the preferences of the user. Settings that have not been set have their default values
+// @Summary Get preferences
+// @Tags users
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Success 200 {object} common.Response{data=user.Preferences}
+// @Failure 500 {object} server.HTTPError
+// @Router /api/v1/me/preferences [get]
+func GetPreferences(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		appUser := middleware.UserFromContext(r.Context())
+
+		preferences, cErr := app.Controllers.User.GetPreferences(appUser)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: preferences,
+		})
+	}
+}
+
+// SetPreferences func Set preferences
+// @Description Replace the preferences of the user. Settings that are left out get their default values
+// @Summary Set preferences
+// @Tags users
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param preferences body user.Preferences true "Preferences"
+// @Success 200 {object} common.Response{data=user.Preferences}
+// @Failure 500 {object} server.HTTPError
+// @Failure 400 {object} server.HTTPError
+// @Router /api/v1/me/preferences [put]
+func SetPreferences(app *application.Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		preferences := user.DefaultPreferences()
+		if err := app.Srv.Decode(w, r, &preferences); err != nil {
+			app.Srv.RespondError(w, r, http.StatusBadRequest, fmt.Errorf("could not parse body: %w", err))
+			return
+		}
+
+		appUser := middleware.UserFromContext(r.Context())
+
+		updatedPreferences, cErr := app.Controllers.User.SetPreferences(appUser, &preferences)
+		if cErr != nil {
+			app.Srv.RespondError(w, r, cErr.StatusCode, cErr.Err)
+			return
+		}
+
+		app.Srv.Respond(w, r, http.StatusOK, common.Response{
+			Data: updatedPreferences,
+		})
+	}
+}
@@ -6,12 +6,13 @@ import (
 	"ShoppingList-Backend/pkg/application"
 	"ShoppingList-Backend/pkg/config"
 	"ShoppingList-Backend/pkg/logger"
+	"ShoppingList-Backend/pkg/middleware"
 	"ShoppingList-Backend/pkg/server"
 	"log"
+	"net/http"
 
 	"github.com/gorilla/mux"
 	"github.com/joho/godotenv"
-	"github.com/rs/cors"
 	"github.com/urfave/negroni"
 	"go.uber.org/zap"
 )
@@ -45,17 +46,25 @@ func main() {
 
 	r := mux.NewRouter().StrictSlash(true)
 
-	corsMiddleware := cors.New(cors.Options{
-		AllowedOrigins: []string{"*"}, // TODO: Maybe consider not allowing all origins. For now it's fine
-		AllowedHeaders: []string{"Authorization", "Content-Type"},
-		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
-		Debug:          false,
-	})
-	n := negroni.New(corsMiddleware, negroni.NewRecovery(), negroni.NewLogger())
+	corsMiddleware, err := middleware.NewCors(cfg)
+	if err != nil {
+		zap.S().Fatalf("CORS setup error: %v", err)
+	}
+	n := negroni.New(corsMiddleware, negroni.NewRecovery())
 
-	n.UseHandler(r)
+	// Tickets are sent as query parameters, so they must not end up in the logs
+	requestLogger := middleware.NewZapLogger(middleware.Config{
+		RedactedQueryParams: []string{"ticket", "Authorization", "access_token"},
+		// Probes are made every few seconds, and would drown out the other requests
+		Next: func(r *http.Request) bool {
+			return r.URL.Path == "/healthz" || r.URL.Path == "/readyz"
+		},
+	})
+	n.UseHandler(requestLogger(r))
 
 	router.SwaggerRoute(app, r)
+	router.HealthRoutes(app, r)
+	router.PublicRoutes(app, r)
 	router.PrivateRoutes(app, r)
 	router.SocketIoRoutes(app, r)
 
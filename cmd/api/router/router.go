@@ -1,10 +1,26 @@
 package router
 
 import (
+	accessTokensHandler "ShoppingList-Backend/cmd/api/handlers/accesstokens"
+	demoHandler "ShoppingList-Backend/cmd/api/handlers/demo"
+	householdsHandler "ShoppingList-Backend/cmd/api/handlers/households"
 	itemsHandler "ShoppingList-Backend/cmd/api/handlers/items"
 	listsHandler "ShoppingList-Backend/cmd/api/handlers/lists"
+	mealPlanHandler "ShoppingList-Backend/cmd/api/handlers/mealplan"
+	pantryHandler "ShoppingList-Backend/cmd/api/handlers/pantry"
+	pricesHandler "ShoppingList-Backend/cmd/api/handlers/prices"
+	recipesHandler "ShoppingList-Backend/cmd/api/handlers/recipes"
+	recurringHandler "ShoppingList-Backend/cmd/api/handlers/recurring"
+	statsHandler "ShoppingList-Backend/cmd/api/handlers/stats"
+	ticketsHandler "ShoppingList-Backend/cmd/api/handlers/tickets"
+	usersHandler "ShoppingList-Backend/cmd/api/handlers/users"
+	"ShoppingList-Backend/internal/pkg/accesstoken"
+	"ShoppingList-Backend/internal/pkg/user"
 	"ShoppingList-Backend/pkg/application"
+	"ShoppingList-Backend/pkg/events"
+	"ShoppingList-Backend/pkg/health"
 	"ShoppingList-Backend/pkg/middleware"
+	"fmt"
 	"net/http"
 
 	socketio "github.com/googollee/go-socket.io"
@@ -18,50 +34,205 @@ import (
 	_ "ShoppingList-Backend/api"
 )
 
+// Set by the server on socket.io requests, after the user has been authenticated
+var headerSocketIoUserId = http.CanonicalHeaderKey("X-SocketIo-User-ID")
+
 func SocketIoRoutes(app *application.Application, r *mux.Router) {
 	app.SocketIo.OnConnect("/", func(c socketio.Conn) error {
 		c.SetContext("")
-		zap.S().Infow("connected:", "id", c.ID())
+		userID := c.RemoteHeader().Get(headerSocketIoUserId)
+		if userID == "" {
+			return fmt.Errorf("unauthenticated socket.io connection")
+		}
+		c.Join(events.UserRoom(userID))
+		// Changes to shared data are published to the household, so join all households the user is a member of.
+		// Households joined later are only picked up on the next connection
+		households, cErr := app.Controllers.Household.GetHouseholds(&user.AppUser{ID: userID})
+		if cErr != nil {
+			zap.S().Warnw("Could not get households of socket.io connection", "userID", userID, "error", cErr.Err)
+		}
+		for _, household := range households {
+			c.Join(events.HouseholdRoom(household.ID))
+		}
+		zap.S().Infow("connected:", "id", c.ID(), "userID", userID)
 		return nil
 	})
 	app.SocketIo.OnEvent("/", "message", func(s socketio.Conn, msg string) {
 		zap.S().Infow("message", "msg", msg)
 		s.Emit("reply", "have "+msg)
 	})
-	r.HandleFunc("/socket.io/", app.SocketIo.ServeHTTP)
+
+	// socket.io connections can only be associated with a user through the headers of the request that opened them
+	socketIoHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Set(headerSocketIoUserId, middleware.UserFromContext(r.Context()).ID)
+		app.SocketIo.ServeHTTP(w, r)
+	})
+	// Browsers cannot set headers on socket.io connections, so the handshake is authenticated with a ticket instead.
+	// The requests that follow are tied to the authenticated session by its ID
+	protectedSocketIoHandler := middleware.JWTProtected(app.Cfg, middleware.WithTickets(app.Authenticator, app.Controllers.Ticket))(socketIoHandler)
+	r.Handle("/socket.io/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sid") != "" {
+			r.Header.Del(headerSocketIoUserId)
+			app.SocketIo.ServeHTTP(w, r)
+			return
+		}
+		protectedSocketIoHandler.ServeHTTP(w, r)
+	}))
+}
+
+// HealthRoutes are the liveness and readiness probes of the deployment
+func HealthRoutes(app *application.Application, r *mux.Router) {
+	r.HandleFunc("/healthz", health.LivenessHandler()).Methods("GET")
+	r.HandleFunc("/readyz", health.ReadinessHandler(app.Readiness)).Methods("GET")
+}
+
+// PublicRoutes are the routes that do not require authentication
+func PublicRoutes(app *application.Application, r *mux.Router) {
+	apiV1 := r.PathPrefix("/api/v1").Subrouter()
+
+	// Demo users
+	demo := apiV1.PathPrefix("/demo").Subrouter()
+	demo.Use(middleware.RateLimited(app.RateLimiter, "demo"))
+	demo.HandleFunc("", demoHandler.ProvisionDemoUser(app)).Methods("POST")
 }
 
 func PrivateRoutes(app *application.Application, r *mux.Router) {
 	apiV1 := r.PathPrefix("/api/v1").Subrouter()
 
+	// Profile
+	me := apiV1.PathPrefix("/me").Subrouter()
+	me.Use(middleware.JWTProtected(app.Cfg, app.Authenticator))
+	me.Use(middleware.RateLimited(app.RateLimiter, "me"))
+	me.Use(middleware.UserSynced(app.Controllers.User))
+	me.HandleFunc("", usersHandler.GetMe(app)).Methods("GET")
+	me.HandleFunc("", usersHandler.UpdateMe(app)).Methods("PATCH")
+	me.HandleFunc("/preferences", usersHandler.GetPreferences(app)).Methods("GET")
+	me.HandleFunc("/preferences", usersHandler.SetPreferences(app)).Methods("PUT")
+
+	// Personal access tokens, which cannot be used to manage tokens themselves
+	tokens := me.PathPrefix("/tokens").Subrouter()
+	tokens.Use(middleware.RejectAccessTokens())
+	tokens.HandleFunc("", accessTokensHandler.GetAccessTokens(app)).Methods("GET")
+	tokens.HandleFunc("", accessTokensHandler.CreateAccessToken(app)).Methods("POST")
+	tokens.HandleFunc("/{id}", accessTokensHandler.DeleteAccessToken(app)).Methods("DELETE")
+
+	// Tickets for connections that cannot send an Authorization header
+	tickets := apiV1.PathPrefix("/tickets").Subrouter()
+	tickets.Use(middleware.JWTProtected(app.Cfg, app.Authenticator))
+	tickets.Use(middleware.RateLimited(app.RateLimiter, "tickets"))
+	tickets.HandleFunc("", ticketsHandler.CreateTicket(app)).Methods("POST")
+
+	// Households
+	households := apiV1.PathPrefix("/households").Subrouter()
+	households.Use(middleware.JWTProtected(app.Cfg, app.Authenticator))
+	households.Use(middleware.RateLimited(app.RateLimiter, "households"))
+	households.Use(middleware.UserSynced(app.Controllers.User))
+	households.HandleFunc("", householdsHandler.GetHouseholds(app)).Methods("GET")
+	households.HandleFunc("", householdsHandler.CreateHousehold(app)).Methods("POST")
+	households.HandleFunc("/{id}/members", householdsHandler.GetHouseholdMembers(app)).Methods("GET")
+	households.HandleFunc("/{id}/members", householdsHandler.SetHouseholdMember(app)).Methods("PUT")
+	households.HandleFunc("/{id}/members/{userId}", householdsHandler.RemoveHouseholdMember(app)).Methods("DELETE")
+
 	// Items
 	items := apiV1.PathPrefix("/items").Subrouter()
-	items.Use(middleware.JWTProtected(app.Cfg))
+	items.Use(middleware.JWTProtected(app.Cfg, app.Authenticator))
+	items.Use(middleware.RateLimited(app.RateLimiter, "items"))
+	items.Use(middleware.UserSynced(app.Controllers.User))
+	items.Use(middleware.HouseholdScoped(app.Controllers.Household))
 	items.HandleFunc("", itemsHandler.GetItems(app)).Methods("GET")
 	items.HandleFunc("", itemsHandler.CreateItem(app)).Methods("POST")
 	items.HandleFunc("/{id}", itemsHandler.UpdateItem(app)).Methods("PUT")
 	items.HandleFunc("/{id}", itemsHandler.DeleteItem(app)).Methods("DELETE")
+	items.HandleFunc("/{id}/creator", itemsHandler.GetItemCreator(app)).Methods("GET")
+	items.HandleFunc("/{id}/merge", itemsHandler.MergeItems(app)).Methods("POST")
+	items.HandleFunc("/{id}/prices", pricesHandler.GetPrices(app)).Methods("GET")
+	items.HandleFunc("/{id}/prices", pricesHandler.CreatePrice(app)).Methods("POST")
+	items.HandleFunc("/{id}/prices/{priceId}", pricesHandler.DeletePrice(app)).Methods("DELETE")
 
 	// Lists
 	lists := apiV1.PathPrefix("/lists").Subrouter()
-	lists.Use(middleware.JWTProtected(app.Cfg))
+	lists.Use(middleware.ScopedAccessTokens(accesstoken.ScopeListsRead, accesstoken.ScopeListsWrite))
+	lists.Use(middleware.JWTProtected(app.Cfg, app.Authenticator))
+	lists.Use(middleware.RateLimited(app.RateLimiter, "lists"))
+	lists.Use(middleware.UserSynced(app.Controllers.User))
+	lists.Use(middleware.HouseholdScoped(app.Controllers.Household))
 
 	lists.HandleFunc("", listsHandler.GetLists(app)).Methods("GET")
 	lists.HandleFunc("/default", listsHandler.GetDefaultList(app)).Methods("GET")
 	lists.HandleFunc("", listsHandler.CreateList(app)).Methods("POST")
+	lists.HandleFunc("/{id}", listsHandler.GetList(app)).Methods("GET")
 	lists.HandleFunc("/{id}", listsHandler.UpdateList(app)).Methods("PUT")
 	lists.HandleFunc("/{id}/default", listsHandler.SetDefaultList(app)).Methods("PUT")
+	lists.HandleFunc("/{id}/budget", listsHandler.SetListBudget(app)).Methods("PUT")
+	lists.HandleFunc("/{id}/budget", listsHandler.DeleteListBudget(app)).Methods("DELETE")
+	lists.HandleFunc("/{id}/auto-clear", listsHandler.SetListAutoClear(app)).Methods("PUT")
+	lists.HandleFunc("/{id}/auto-clear", listsHandler.DeleteListAutoClear(app)).Methods("DELETE")
+	lists.HandleFunc("/{id}/suggestions", listsHandler.GetListSuggestions(app)).Methods("GET")
 	lists.HandleFunc("/{id}", listsHandler.DeleteList(app)).Methods("DELETE")
 	lists.HandleFunc("/{id}/items/crossed", listsHandler.ClearCrossedListItems(app)).Methods("DELETE")
+	lists.HandleFunc("/{id}/items/barcode", listsHandler.AddBarcodeToList(app)).Methods("POST")
 	lists.HandleFunc("/{id}/items/{itemId}", listsHandler.AddItemToList(app)).Methods("POST")
 	lists.HandleFunc("/{id}/items/{listItemId}", listsHandler.UpdateListItem(app)).Methods("PUT")
 	lists.HandleFunc("/{id}/items/{listItemId}", listsHandler.RemoveItemFromList(app)).Methods("DELETE")
 
+	// Recipes
+	recipes := apiV1.PathPrefix("/recipes").Subrouter()
+	recipes.Use(middleware.JWTProtected(app.Cfg, app.Authenticator))
+	recipes.Use(middleware.RateLimited(app.RateLimiter, "recipes"))
+	recipes.Use(middleware.UserSynced(app.Controllers.User))
+	recipes.Use(middleware.HouseholdScoped(app.Controllers.Household))
+	recipes.HandleFunc("", recipesHandler.GetRecipes(app)).Methods("GET")
+	recipes.HandleFunc("", recipesHandler.CreateRecipe(app)).Methods("POST")
+	recipes.HandleFunc("/{id}", recipesHandler.GetRecipe(app)).Methods("GET")
+	recipes.HandleFunc("/{id}", recipesHandler.UpdateRecipe(app)).Methods("PUT")
+	recipes.HandleFunc("/{id}", recipesHandler.DeleteRecipe(app)).Methods("DELETE")
+	recipes.HandleFunc("/{id}/list", recipesHandler.AddRecipeToList(app)).Methods("POST")
+
+	// Meal plan
+	mealPlan := apiV1.PathPrefix("/mealplan").Subrouter()
+	mealPlan.Use(middleware.JWTProtected(app.Cfg, app.Authenticator))
+	mealPlan.Use(middleware.RateLimited(app.RateLimiter, "mealplan"))
+	mealPlan.Use(middleware.UserSynced(app.Controllers.User))
+	mealPlan.Use(middleware.HouseholdScoped(app.Controllers.Household))
+	mealPlan.HandleFunc("", mealPlanHandler.GetMealPlan(app)).Methods("GET")
+	mealPlan.HandleFunc("", mealPlanHandler.CreateMealPlanEntry(app)).Methods("POST")
+	mealPlan.HandleFunc("/shopping-list", mealPlanHandler.GenerateShoppingList(app)).Methods("POST")
+	mealPlan.HandleFunc("/{id}", mealPlanHandler.DeleteMealPlanEntry(app)).Methods("DELETE")
+
+	// Pantry
+	pantry := apiV1.PathPrefix("/pantry").Subrouter()
+	pantry.Use(middleware.JWTProtected(app.Cfg, app.Authenticator))
+	pantry.Use(middleware.RateLimited(app.RateLimiter, "pantry"))
+	pantry.Use(middleware.UserSynced(app.Controllers.User))
+	pantry.Use(middleware.HouseholdScoped(app.Controllers.Household))
+	pantry.HandleFunc("", pantryHandler.GetPantryItems(app)).Methods("GET")
+	pantry.HandleFunc("/{itemId}", pantryHandler.UpdatePantryItem(app)).Methods("PUT")
+	pantry.HandleFunc("/{itemId}/consume", pantryHandler.ConsumePantryItem(app)).Methods("POST")
+
+	// Recurring items
+	recurringItems := apiV1.PathPrefix("/recurring-items").Subrouter()
+	recurringItems.Use(middleware.JWTProtected(app.Cfg, app.Authenticator))
+	recurringItems.Use(middleware.RateLimited(app.RateLimiter, "recurring-items"))
+	recurringItems.Use(middleware.UserSynced(app.Controllers.User))
+	recurringItems.Use(middleware.HouseholdScoped(app.Controllers.Household))
+	recurringItems.HandleFunc("", recurringHandler.GetRecurringItems(app)).Methods("GET")
+	recurringItems.HandleFunc("", recurringHandler.CreateRecurringItem(app)).Methods("POST")
+	recurringItems.HandleFunc("/{id}", recurringHandler.UpdateRecurringItem(app)).Methods("PUT")
+	recurringItems.HandleFunc("/{id}", recurringHandler.DeleteRecurringItem(app)).Methods("DELETE")
+
+	// Stats
+	stats := apiV1.PathPrefix("/stats").Subrouter()
+	stats.Use(middleware.JWTProtected(app.Cfg, app.Authenticator))
+	stats.Use(middleware.RateLimited(app.RateLimiter, "stats"))
+	stats.Use(middleware.UserSynced(app.Controllers.User))
+	stats.Use(middleware.HouseholdScoped(app.Controllers.Household))
+	stats.HandleFunc("/items", statsHandler.GetItemStats(app)).Methods("GET")
+
 	// // SSE
 	// sse := apiV1.PathPrefix("/sse").Subrouter()
 
 	// sseTicket := sse.PathPrefix("/ticket").Subrouter()
-	// sseTicket.Use(middleware.JWTProtected(app.Cfg))
+	// sseTicket.Use(middleware.JWTProtected(app.Cfg, app.Authenticator))
 	// sseTicket.HandleFunc("/", handlers.CreateSseTicket(app)).Methods("POST")
 
 	// sse.HandleFunc("/events", handlers.SseEvents(app)).Methods("GET")
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Probes the API or worker in the same container, for the HEALTHCHECK of the image, which has no shell or curl.
+// Exits with 1 if the probe fails. Liveness is the default, since Docker restarts unhealthy containers,
+// and an outage of Postgres or Redis must not restart every container. Readiness is for routing traffic
+func main() {
+	service := flag.String("service", "api", "Which service to check: api or worker")
+	probe := flag.String("probe", "liveness", "Which probe to make: liveness (/healthz) or readiness (/readyz)")
+	flag.Parse()
+
+	var path string
+	switch *probe {
+	case "liveness":
+		path = "/healthz"
+	case "readiness":
+		path = "/readyz"
+	default:
+		fmt.Fprintf(os.Stderr, "unknown probe %v\n", *probe)
+		os.Exit(1)
+	}
+
+	// The ports are configured in the same files as the services read
+	_ = godotenv.Load()
+	_ = godotenv.Load("/run/secrets/env")
+
+	var port string
+	switch *service {
+	case "api":
+		port = os.Getenv("API_PORT")
+	case "worker":
+		port = os.Getenv("WORKER_HEALTH_PORT")
+		if port == "" {
+			port = "8081"
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown service %v\n", *service)
+		os.Exit(1)
+	}
+
+	client := http.Client{Timeout: 5 * time.Second}
+	res, err := client.Get(fmt.Sprintf("http://127.0.0.1:%v%v", port, path))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v is not reachable: %v\n", *service, err)
+		os.Exit(1)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		fmt.Fprintf(os.Stderr, "%v failed the %v probe: %v\n", *service, *probe, res.Status)
+		os.Exit(1)
+	}
+}
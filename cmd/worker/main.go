@@ -31,15 +31,21 @@ func main() {
 	}
 
 	var wg sync.WaitGroup
-	wg.Add(2)
+	wg.Add(3)
 
 	pool := worker.NewWorkerPool(app)
 	pool.PeriodicallyEnqueue("20 40 7 * * *", worker.JobDemoCleanUp)
+	pool.PeriodicallyEnqueue("0 15 * * * *", worker.JobGenerateSuggestions)
+	pool.PeriodicallyEnqueue("0 */5 * * * *", worker.JobAddRecurringItems)
+	pool.PeriodicallyEnqueue("30 */10 * * * *", worker.JobAutoClearCrossedListItems)
 	go worker.Start(pool, &wg)
 
 	webuiServer := worker.NewWebUI(app)
 	go worker.StartWebUI(webuiServer, &wg)
 
+	healthServer := worker.NewHealthServer(app)
+	go worker.StartHealthServer(healthServer, &wg)
+
 	wg.Wait()
 
 }
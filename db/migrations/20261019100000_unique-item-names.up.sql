@@ -0,0 +1,24 @@
+-- Collapse whitespace in existing item names
+UPDATE items SET name = regexp_replace(btrim(name), '\s+', ' ', 'g');
+
+-- Fold duplicates (same owner, same name ignoring case) into the oldest item
+WITH ranked AS (
+  SELECT id, first_value(id) OVER (
+    PARTITION BY owner_id, lower(name) ORDER BY created_at ASC, id ASC
+  ) AS keep_id
+  FROM items
+  WHERE deleted_at IS NULL
+),
+duplicates AS (
+  SELECT id, keep_id FROM ranked WHERE id <> keep_id
+),
+repointed AS (
+  UPDATE list_item SET item_id = duplicates.keep_id, updated_at = NOW()
+  FROM duplicates
+  WHERE list_item.item_id = duplicates.id
+)
+UPDATE items SET deleted_at = NOW()
+FROM duplicates
+WHERE items.id = duplicates.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS items_owner_id_name_key ON items (owner_id, lower(name)) WHERE deleted_at IS NULL;
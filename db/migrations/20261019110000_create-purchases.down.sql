@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS purchases;
+ALTER TABLE list_item DROP COLUMN IF EXISTS quantity;
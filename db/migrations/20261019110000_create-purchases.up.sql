@@ -0,0 +1,13 @@
+ALTER TABLE list_item ADD COLUMN IF NOT EXISTS quantity DOUBLE PRECISION NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS purchases (
+  id UUID DEFAULT uuid_generate_v4 () PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  purchased_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  item_id UUID REFERENCES items (id) ON DELETE CASCADE,
+  list_id UUID REFERENCES lists (id) ON DELETE SET NULL,
+  user_id VARCHAR(36) NOT NULL,
+  quantity DOUBLE PRECISION NOT NULL DEFAULT 1
+);
+
+CREATE INDEX IF NOT EXISTS purchases_user_id_purchased_at_idx ON purchases (user_id, purchased_at);
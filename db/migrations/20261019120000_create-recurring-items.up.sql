@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS recurring_items (
+  id UUID DEFAULT uuid_generate_v4 () PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE NULL,
+  deleted_at TIMESTAMP WITH TIME ZONE NULL,
+  owner_id VARCHAR(36) NOT NULL,
+  item_id UUID REFERENCES items (id) ON DELETE CASCADE,
+  list_id UUID REFERENCES lists (id) ON DELETE CASCADE,
+  cron VARCHAR(255) NULL,
+  interval_days INTEGER NULL,
+  quantity DOUBLE PRECISION NOT NULL DEFAULT 1,
+  next_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  last_run_at TIMESTAMP WITH TIME ZONE NULL,
+  CHECK ((cron IS NULL) <> (interval_days IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS recurring_items_next_run_at_idx ON recurring_items (next_run_at) WHERE deleted_at IS NULL;
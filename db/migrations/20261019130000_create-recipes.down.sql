@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS recipe_ingredients;
+DROP TABLE IF EXISTS recipes;
+ALTER TABLE list_item DROP COLUMN IF EXISTS unit;
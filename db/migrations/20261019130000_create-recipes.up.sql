@@ -0,0 +1,20 @@
+ALTER TABLE list_item ADD COLUMN IF NOT EXISTS unit VARCHAR(20) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS recipes (
+  id UUID DEFAULT uuid_generate_v4 () PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE NULL,
+  deleted_at TIMESTAMP WITH TIME ZONE NULL,
+  owner_id VARCHAR(36) NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  servings INTEGER NOT NULL CHECK (servings > 0)
+);
+
+CREATE TABLE IF NOT EXISTS recipe_ingredients (
+  id UUID DEFAULT uuid_generate_v4 () PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  recipe_id UUID REFERENCES recipes (id) ON DELETE CASCADE,
+  item_id UUID REFERENCES items (id) ON DELETE CASCADE,
+  quantity DOUBLE PRECISION NOT NULL,
+  unit VARCHAR(20) NOT NULL DEFAULT ''
+);
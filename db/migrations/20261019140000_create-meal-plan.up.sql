@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS meal_plan_entries (
+  id UUID DEFAULT uuid_generate_v4 () PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE NULL,
+  owner_id VARCHAR(36) NOT NULL,
+  date DATE NOT NULL,
+  recipe_id UUID REFERENCES recipes (id) ON DELETE CASCADE,
+  servings INTEGER NULL CHECK (servings > 0)
+);
+
+CREATE INDEX IF NOT EXISTS meal_plan_entries_owner_id_date_idx ON meal_plan_entries (owner_id, date);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS pantry_items;
+ALTER TABLE list_item DROP COLUMN IF EXISTS stocked;
@@ -0,0 +1,14 @@
+-- Whether the quantity of the list item has been added to the pantry, which happens when it is crossed
+ALTER TABLE list_item ADD COLUMN IF NOT EXISTS stocked BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS pantry_items (
+  id UUID DEFAULT uuid_generate_v4 () PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE NULL,
+  owner_id VARCHAR(36) NOT NULL,
+  item_id UUID REFERENCES items (id) ON DELETE CASCADE,
+  quantity DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+  unit VARCHAR(20) NOT NULL DEFAULT '',
+  min_quantity DOUBLE PRECISION NULL,
+  UNIQUE (owner_id, item_id)
+);
@@ -0,0 +1,14 @@
+-- Prices are in minor units (e.g. cents) of the currency, per unit of the item
+CREATE TABLE IF NOT EXISTS prices (
+  id UUID DEFAULT uuid_generate_v4 () PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  owner_id VARCHAR(36) NOT NULL,
+  item_id UUID REFERENCES items (id) ON DELETE CASCADE,
+  store VARCHAR(255) NOT NULL DEFAULT '',
+  amount BIGINT NOT NULL CHECK (amount >= 0),
+  currency CHAR(3) NOT NULL,
+  unit VARCHAR(20) NOT NULL DEFAULT '',
+  recorded_at DATE NOT NULL DEFAULT CURRENT_DATE
+);
+
+CREATE INDEX IF NOT EXISTS prices_item_id_recorded_at_idx ON prices (item_id, recorded_at DESC);
@@ -0,0 +1,3 @@
+ALTER TABLE lists DROP COLUMN IF EXISTS budget_exceeded;
+ALTER TABLE lists DROP COLUMN IF EXISTS budget_currency;
+ALTER TABLE lists DROP COLUMN IF EXISTS budget_amount;
@@ -0,0 +1,5 @@
+-- Budget in minor units of the currency
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS budget_amount BIGINT NULL CHECK (budget_amount >= 0);
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS budget_currency CHAR(3) NULL;
+-- Whether the estimated cost exceeded the budget the last time it was computed, so the event is only sent once
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS budget_exceeded BOOLEAN NOT NULL DEFAULT FALSE;
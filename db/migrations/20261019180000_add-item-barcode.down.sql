@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS items_owner_id_barcode_key;
+
+ALTER TABLE items DROP COLUMN IF EXISTS barcode;
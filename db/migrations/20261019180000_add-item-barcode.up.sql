@@ -0,0 +1,3 @@
+ALTER TABLE items ADD COLUMN IF NOT EXISTS barcode VARCHAR(13) NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS items_owner_id_barcode_key ON items (owner_id, barcode) WHERE deleted_at IS NULL AND barcode IS NOT NULL;
@@ -0,0 +1,36 @@
+-- Data in shared households is kept, but goes back to being owned by the user that created it
+ALTER TABLE pantry_items ADD COLUMN IF NOT EXISTS owner_id VARCHAR(36) NULL;
+UPDATE pantry_items SET owner_id = households.created_by FROM households WHERE households.id = pantry_items.household_id;
+DELETE FROM pantry_items a USING pantry_items b WHERE a.owner_id = b.owner_id AND a.item_id = b.item_id AND a.id < b.id;
+ALTER TABLE pantry_items ALTER COLUMN owner_id SET NOT NULL;
+ALTER TABLE pantry_items DROP CONSTRAINT IF EXISTS pantry_items_household_id_item_id_key;
+ALTER TABLE pantry_items ADD CONSTRAINT pantry_items_owner_id_item_id_key UNIQUE (owner_id, item_id);
+ALTER TABLE pantry_items DROP COLUMN IF EXISTS household_id;
+
+ALTER TABLE prices DROP COLUMN IF EXISTS household_id;
+
+DROP INDEX IF EXISTS meal_plan_entries_household_id_date_idx;
+ALTER TABLE meal_plan_entries DROP COLUMN IF EXISTS household_id;
+
+ALTER TABLE recipes DROP COLUMN IF EXISTS household_id;
+
+ALTER TABLE recurring_items DROP COLUMN IF EXISTS household_id;
+
+DROP INDEX IF EXISTS purchases_household_id_purchased_at_idx;
+ALTER TABLE purchases DROP COLUMN IF EXISTS household_id;
+
+DROP INDEX IF EXISTS default_lists_app_user_id_household_id_key;
+DELETE FROM default_lists a USING default_lists b WHERE a.app_user_id = b.app_user_id AND a.created_at < b.created_at;
+ALTER TABLE default_lists DROP COLUMN IF EXISTS household_id;
+
+DROP INDEX IF EXISTS lists_household_id_idx;
+ALTER TABLE lists DROP COLUMN IF EXISTS household_id;
+
+DROP INDEX IF EXISTS items_household_id_barcode_key;
+DROP INDEX IF EXISTS items_household_id_name_key;
+ALTER TABLE items DROP COLUMN IF EXISTS household_id;
+CREATE UNIQUE INDEX IF NOT EXISTS items_owner_id_name_key ON items (owner_id, lower(name)) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS items_owner_id_barcode_key ON items (owner_id, barcode) WHERE deleted_at IS NULL AND barcode IS NOT NULL;
+
+DROP TABLE IF EXISTS household_members;
+DROP TABLE IF EXISTS households;
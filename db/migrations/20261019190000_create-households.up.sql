@@ -0,0 +1,91 @@
+CREATE TABLE IF NOT EXISTS households (
+  id UUID DEFAULT uuid_generate_v4 () PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE NULL,
+  name VARCHAR(255) NOT NULL,
+  -- Every user has one personal household, which is used when a request does not choose a household
+  personal BOOLEAN NOT NULL DEFAULT FALSE,
+  created_by VARCHAR(36) NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS households_personal_key ON households (created_by) WHERE personal;
+
+CREATE TABLE IF NOT EXISTS household_members (
+  household_id UUID REFERENCES households (id) ON DELETE CASCADE,
+  user_id VARCHAR(36) NOT NULL,
+  role VARCHAR(20) NOT NULL DEFAULT 'member',
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  PRIMARY KEY (household_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS household_members_user_id_idx ON household_members (user_id);
+
+-- Move the existing data of each user into a personal household
+INSERT INTO households (name, personal, created_by)
+SELECT 'Personal', TRUE, users.user_id FROM (
+  SELECT owner_id AS user_id FROM items
+  UNION SELECT owner_id FROM lists
+  UNION SELECT app_user_id FROM default_lists
+  UNION SELECT user_id FROM purchases
+  UNION SELECT owner_id FROM recurring_items
+  UNION SELECT owner_id FROM recipes
+  UNION SELECT owner_id FROM meal_plan_entries
+  UNION SELECT owner_id FROM pantry_items
+  UNION SELECT owner_id FROM prices
+) AS users
+ON CONFLICT DO NOTHING;
+
+INSERT INTO household_members (household_id, user_id, role)
+SELECT id, created_by, 'owner' FROM households WHERE personal
+ON CONFLICT DO NOTHING;
+
+-- owner_id is kept on the tables below as the user that created the row
+ALTER TABLE items ADD COLUMN IF NOT EXISTS household_id UUID REFERENCES households (id) ON DELETE CASCADE;
+UPDATE items SET household_id = households.id FROM households WHERE households.personal AND households.created_by = items.owner_id;
+ALTER TABLE items ALTER COLUMN household_id SET NOT NULL;
+DROP INDEX IF EXISTS items_owner_id_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS items_household_id_name_key ON items (household_id, lower(name)) WHERE deleted_at IS NULL;
+DROP INDEX IF EXISTS items_owner_id_barcode_key;
+CREATE UNIQUE INDEX IF NOT EXISTS items_household_id_barcode_key ON items (household_id, barcode) WHERE deleted_at IS NULL AND barcode IS NOT NULL;
+
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS household_id UUID REFERENCES households (id) ON DELETE CASCADE;
+UPDATE lists SET household_id = households.id FROM households WHERE households.personal AND households.created_by = lists.owner_id;
+ALTER TABLE lists ALTER COLUMN household_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS lists_household_id_idx ON lists (household_id) WHERE deleted_at IS NULL;
+
+-- Each user has a default list per household
+DELETE FROM default_lists WHERE list_id IS NULL;
+ALTER TABLE default_lists ADD COLUMN IF NOT EXISTS household_id UUID REFERENCES households (id) ON DELETE CASCADE;
+UPDATE default_lists SET household_id = lists.household_id FROM lists WHERE lists.id = default_lists.list_id;
+ALTER TABLE default_lists ALTER COLUMN household_id SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS default_lists_app_user_id_household_id_key ON default_lists (app_user_id, household_id);
+
+ALTER TABLE purchases ADD COLUMN IF NOT EXISTS household_id UUID REFERENCES households (id) ON DELETE CASCADE;
+UPDATE purchases SET household_id = households.id FROM households WHERE households.personal AND households.created_by = purchases.user_id;
+ALTER TABLE purchases ALTER COLUMN household_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS purchases_household_id_purchased_at_idx ON purchases (household_id, purchased_at);
+
+ALTER TABLE recurring_items ADD COLUMN IF NOT EXISTS household_id UUID REFERENCES households (id) ON DELETE CASCADE;
+UPDATE recurring_items SET household_id = households.id FROM households WHERE households.personal AND households.created_by = recurring_items.owner_id;
+ALTER TABLE recurring_items ALTER COLUMN household_id SET NOT NULL;
+
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS household_id UUID REFERENCES households (id) ON DELETE CASCADE;
+UPDATE recipes SET household_id = households.id FROM households WHERE households.personal AND households.created_by = recipes.owner_id;
+ALTER TABLE recipes ALTER COLUMN household_id SET NOT NULL;
+
+ALTER TABLE meal_plan_entries ADD COLUMN IF NOT EXISTS household_id UUID REFERENCES households (id) ON DELETE CASCADE;
+UPDATE meal_plan_entries SET household_id = households.id FROM households WHERE households.personal AND households.created_by = meal_plan_entries.owner_id;
+ALTER TABLE meal_plan_entries ALTER COLUMN household_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS meal_plan_entries_household_id_date_idx ON meal_plan_entries (household_id, date);
+
+ALTER TABLE prices ADD COLUMN IF NOT EXISTS household_id UUID REFERENCES households (id) ON DELETE CASCADE;
+UPDATE prices SET household_id = households.id FROM households WHERE households.personal AND households.created_by = prices.owner_id;
+ALTER TABLE prices ALTER COLUMN household_id SET NOT NULL;
+
+-- The pantry belongs to the household as a whole, so it has no owner
+ALTER TABLE pantry_items ADD COLUMN IF NOT EXISTS household_id UUID REFERENCES households (id) ON DELETE CASCADE;
+UPDATE pantry_items SET household_id = households.id FROM households WHERE households.personal AND households.created_by = pantry_items.owner_id;
+ALTER TABLE pantry_items ALTER COLUMN household_id SET NOT NULL;
+ALTER TABLE pantry_items DROP CONSTRAINT IF EXISTS pantry_items_owner_id_item_id_key;
+ALTER TABLE pantry_items DROP COLUMN IF EXISTS owner_id;
+ALTER TABLE pantry_items ADD CONSTRAINT pantry_items_household_id_item_id_key UNIQUE (household_id, item_id);
@@ -0,0 +1,13 @@
+-- Profiles of the users, created and kept up to date from the claims of their JWTs
+CREATE TABLE IF NOT EXISTS users (
+  id VARCHAR(36) PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE NULL,
+  name VARCHAR(255) NOT NULL DEFAULT '',
+  email VARCHAR(255) NOT NULL DEFAULT '',
+  preferred_username VARCHAR(255) NOT NULL DEFAULT '',
+  -- Set by the user, takes precedence over the name from the claims
+  display_name VARCHAR(255) NULL,
+  locale VARCHAR(35) NULL,
+  preferences JSONB NOT NULL DEFAULT '{}'
+);
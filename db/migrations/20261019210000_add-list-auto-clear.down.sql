@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS list_item_list_id_crossed_idx;
+ALTER TABLE lists DROP COLUMN IF EXISTS auto_clear_after_hours;
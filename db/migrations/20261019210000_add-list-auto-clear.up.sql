@@ -0,0 +1,3 @@
+-- Crossed items are cleared this many hours after they were crossed
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS auto_clear_after_hours INTEGER NULL CHECK (auto_clear_after_hours > 0);
+CREATE INDEX IF NOT EXISTS list_item_list_id_crossed_idx ON list_item (list_id) WHERE crossed;
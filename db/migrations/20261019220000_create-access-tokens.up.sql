@@ -0,0 +1,17 @@
+-- Personal access tokens, for scripts and integrations that cannot use OIDC. Only a hash of the token is stored
+CREATE TABLE IF NOT EXISTS access_tokens (
+  id UUID DEFAULT uuid_generate_v4 () PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name VARCHAR(255) NOT NULL,
+  -- The start of the token, to tell tokens apart
+  token_prefix VARCHAR(16) NOT NULL,
+  -- Hex encoded SHA-256 of the token
+  token_hash VARCHAR(64) NOT NULL UNIQUE,
+  -- Space separated. A token without scopes has the same access as the user
+  scopes VARCHAR(255) NOT NULL DEFAULT '',
+  expires_at TIMESTAMP WITH TIME ZONE NULL,
+  last_used_at TIMESTAMP WITH TIME ZONE NULL
+);
+
+CREATE INDEX IF NOT EXISTS access_tokens_user_id_idx ON access_tokens (user_id);
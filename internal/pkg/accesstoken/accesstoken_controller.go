@@ -0,0 +1,136 @@
+package accesstoken
+
+import (
+	"ShoppingList-Backend/internal/pkg/controller"
+	"ShoppingList-Backend/internal/pkg/user"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// The most tokens a user can have
+const maxAccessTokens = 50
+
+type AccessTokenController struct {
+	accessTokenRepo *AccessTokenRepository
+	// Tokens expire after at most this long
+	maxLifetime time.Duration
+}
+
+func NewAccessTokenController(accessTokenRepo *AccessTokenRepository, maxLifetime time.Duration) *AccessTokenController {
+	return &AccessTokenController{
+		accessTokenRepo: accessTokenRepo,
+		maxLifetime:     maxLifetime,
+	}
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// generateToken returns a new token with 256 bits of randomness
+func generateToken() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+	return TokenPrefix + base64.RawURLEncoding.EncodeToString(randomBytes), nil
+}
+
+func (c *AccessTokenController) GetAccessTokens(appUser *user.AppUser) ([]AccessToken, *controller.ControllerError) {
+	accessTokens, err := c.accessTokenRepo.GetAccessTokens(appUser.ID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get access tokens: %w", err))
+	}
+	return accessTokens, nil
+}
+
+func (c *AccessTokenController) CreateAccessToken(appUser *user.AppUser, addAccessToken *AddAccessToken) (*CreatedAccessToken, *controller.ControllerError) {
+	if err := addAccessToken.Validate(time.Now(), c.maxLifetime); err != nil {
+		return nil, controller.CError(http.StatusBadRequest, err)
+	}
+
+	existingTokens, err := c.accessTokenRepo.GetAccessTokens(appUser.ID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get access tokens: %w", err))
+	}
+	if len(existingTokens) >= maxAccessTokens {
+		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("a user can have at most %v access tokens", maxAccessTokens))
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not generate access token: %w", err))
+	}
+
+	scopes := Scopes{}
+	for _, scope := range addAccessToken.Scopes {
+		if !contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	accessTokenToCreate := AccessToken{
+		ID:          uuid.New(),
+		UserID:      appUser.ID,
+		Name:        addAccessToken.Name,
+		TokenPrefix: token[:len(TokenPrefix)+4],
+		TokenHash:   hashToken(token),
+		Scopes:      scopes,
+		ExpiresAt:   addAccessToken.ExpiresAt,
+	}
+	id, err := c.accessTokenRepo.CreateAccessToken(accessTokenToCreate)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not create access token: %w", err))
+	}
+
+	createdAccessToken, err := c.accessTokenRepo.GetAccessToken(id, appUser.ID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get created access token: %w", err))
+	}
+	return &CreatedAccessToken{
+		AccessToken: createdAccessToken,
+		Token:       token,
+	}, nil
+}
+
+func (c *AccessTokenController) DeleteAccessToken(appUser *user.AppUser, id uuid.UUID) (bool, *controller.ControllerError) {
+	if _, err := c.accessTokenRepo.GetAccessToken(id, appUser.ID); err != nil {
+		return false, controller.CError(http.StatusNotFound, fmt.Errorf("access token with ID %v not found: %w", id, err))
+	}
+	if err := c.accessTokenRepo.DeleteAccessToken(id, appUser.ID); err != nil {
+		return false, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not delete access token with ID %v: %w", id, err))
+	}
+	return true, nil
+}
+
+// VerifyAccessToken returns the token if it exists and has not expired, and records that it was used
+func (c *AccessTokenController) VerifyAccessToken(token string) (*AccessToken, *controller.ControllerError) {
+	if !strings.HasPrefix(token, TokenPrefix) {
+		return nil, controller.CError(http.StatusUnauthorized, fmt.Errorf("not a personal access token"))
+	}
+
+	accessToken, err := c.accessTokenRepo.GetActiveAccessToken(hashToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, controller.CError(http.StatusUnauthorized, fmt.Errorf("personal access token is invalid, revoked or expired"))
+	}
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get access token: %w", err))
+	}
+
+	if err := c.accessTokenRepo.TouchAccessToken(accessToken.ID); err != nil {
+		zap.S().Warnw("Could not record use of access token", "id", accessToken.ID, "error", err)
+	}
+	return &accessToken, nil
+}
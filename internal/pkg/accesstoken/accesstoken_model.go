@@ -0,0 +1,106 @@
+package accesstoken
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenPrefix starts every personal access token, so they can be told apart from JWTs
+const TokenPrefix = "slpat_"
+
+const (
+	ScopeListsRead  = "lists:read"
+	ScopeListsWrite = "lists:write"
+)
+
+var scopes = []string{ScopeListsRead, ScopeListsWrite}
+
+type AccessToken struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UserID    string    `db:"user_id" json:"-"`
+
+	Name string `db:"name" json:"name"`
+	// The start of the token, to tell tokens apart
+	TokenPrefix string `db:"token_prefix" json:"tokenPrefix"`
+	TokenHash   string `db:"token_hash" json:"-"`
+	// A token without scopes has the same access as the user
+	Scopes     Scopes     `db:"scopes" json:"scopes"`
+	ExpiresAt  *time.Time `db:"expires_at" json:"expiresAt"`
+	LastUsedAt *time.Time `db:"last_used_at" json:"lastUsedAt"`
+}
+
+// CreatedAccessToken is returned when a token is created. The token itself is not stored, so it cannot be shown again
+type CreatedAccessToken struct {
+	AccessToken
+	Token string `json:"token"`
+}
+
+type AddAccessToken struct {
+	Name string `json:"name"`
+	// lists:read and/or lists:write. Leave empty for the same access as the user
+	Scopes []string `json:"scopes"`
+	// Leave empty for a token that expires after the longest lifetime allowed
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// Validate checks the token to add, and sets it to expire after maxLifetime if it has no expiry
+func (a *AddAccessToken) Validate(now time.Time, maxLifetime time.Duration) error {
+	a.Name = strings.TrimSpace(a.Name)
+	if a.Name == "" {
+		return fmt.Errorf("name must not be empty")
+	}
+	if len(a.Name) > 255 {
+		return fmt.Errorf("name must be at most 255 characters")
+	}
+	for _, scope := range a.Scopes {
+		if !contains(scopes, scope) {
+			return fmt.Errorf("unknown scope %v, must be one of %v", scope, strings.Join(scopes, ", "))
+		}
+	}
+	maxExpiresAt := now.Add(maxLifetime)
+	if a.ExpiresAt == nil {
+		a.ExpiresAt = &maxExpiresAt
+	}
+	if !a.ExpiresAt.After(now) {
+		return fmt.Errorf("expiresAt must be in the future")
+	}
+	if a.ExpiresAt.After(maxExpiresAt) {
+		return fmt.Errorf("expiresAt must be at most %v days in the future", int(maxLifetime.Hours()/24))
+	}
+	return nil
+}
+
+// Scopes are stored space separated
+type Scopes []string
+
+func (s *Scopes) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*s = Scopes{}
+	case []byte:
+		*s = strings.Fields(string(src))
+	case string:
+		*s = strings.Fields(src)
+	default:
+		return fmt.Errorf("cannot scan %T into scopes", src)
+	}
+	return nil
+}
+
+func (s Scopes) Value() (driver.Value, error) {
+	return strings.Join(s, " "), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package accesstoken
+
+import (
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type AccessTokenRepository struct {
+	DB *sqlx.DB
+}
+
+func (q *AccessTokenRepository) GetAccessTokens(userID string) ([]AccessToken, error) {
+	accessTokens := []AccessToken{}
+	query := `SELECT * FROM access_tokens WHERE user_id = $1 ORDER BY created_at DESC`
+	err := q.DB.Select(&accessTokens, query, userID)
+	return accessTokens, err
+}
+
+func (q *AccessTokenRepository) GetAccessToken(id uuid.UUID, userID string) (AccessToken, error) {
+	accessToken := AccessToken{}
+	query := `SELECT * FROM access_tokens WHERE id = $1 AND user_id = $2`
+	err := q.DB.Get(&accessToken, query, id, userID)
+	return accessToken, err
+}
+
+// GetActiveAccessToken returns the token with the hash, if it has not expired
+func (q *AccessTokenRepository) GetActiveAccessToken(tokenHash string) (AccessToken, error) {
+	accessToken := AccessToken{}
+	query := `SELECT * FROM access_tokens WHERE token_hash = $1 AND (expires_at IS NULL OR expires_at > NOW())`
+	err := q.DB.Get(&accessToken, query, tokenHash)
+	return accessToken, err
+}
+
+func (q *AccessTokenRepository) CreateAccessToken(accessToken AccessToken) (uuid.UUID, error) {
+	query := `INSERT INTO access_tokens (id, user_id, name, token_prefix, token_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := q.DB.Exec(query, accessToken.ID, accessToken.UserID, accessToken.Name, accessToken.TokenPrefix, accessToken.TokenHash, accessToken.Scopes, accessToken.ExpiresAt)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return accessToken.ID, nil
+}
+
+func (q *AccessTokenRepository) DeleteAccessToken(id uuid.UUID, userID string) error {
+	query := `DELETE FROM access_tokens WHERE id = $1 AND user_id = $2`
+	_, err := q.DB.Exec(query, id, userID)
+	return err
+}
+
+// TouchAccessToken records that the token was used. It is written at most once a minute per token
+func (q *AccessTokenRepository) TouchAccessToken(id uuid.UUID) error {
+	query := `UPDATE access_tokens SET last_used_at = NOW()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')`
+	_, err := q.DB.Exec(query, id)
+	return err
+}
@@ -0,0 +1,48 @@
+package barcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidBarcode = errors.New("invalid barcode")
+
+// Normalize removes spaces and dashes, which are often used when barcodes are typed in by hand
+func Normalize(code string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code))
+}
+
+// Validate checks that the code is a valid EAN-8, EAN-13 or UPC-A barcode, including the check digit
+func Validate(code string) error {
+	switch len(code) {
+	case 8, 12, 13:
+	default:
+		return fmt.Errorf("%w: %v must be 8 (EAN-8), 12 (UPC-A) or 13 (EAN-13) digits", ErrInvalidBarcode, code)
+	}
+
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("%w: %v must only contain digits", ErrInvalidBarcode, code)
+		}
+	}
+
+	if checkDigit(code[:len(code)-1]) != code[len(code)-1] {
+		return fmt.Errorf("%w: %v has a wrong check digit", ErrInvalidBarcode, code)
+	}
+	return nil
+}
+
+// checkDigit calculates the check digit of the data digits. EAN-8, EAN-13 and UPC-A all weigh the digits 3, 1, 3, ...
+// starting from the rightmost data digit
+func checkDigit(data string) byte {
+	sum := 0
+	for i := 0; i < len(data); i++ {
+		digit := int(data[len(data)-1-i] - '0')
+		if i%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return byte('0' + (10-sum%10)%10)
+}
@@ -0,0 +1,66 @@
+package barcode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+)
+
+var ErrProductNotFound = errors.New("product not found")
+
+type Product struct {
+	Barcode string `json:"barcode"`
+	Name    string `json:"name"`
+}
+
+// ProductDatabase looks up products that are not in the user's catalog yet
+type ProductDatabase interface {
+	LookupProduct(code string) (*Product, error)
+}
+
+// FileProductDatabase is a ProductDatabase backed by a JSON file containing an array of products
+type FileProductDatabase struct {
+	products map[string]Product
+}
+
+// NewFileProductDatabase loads the products in the file into memory. If path is empty, the database is empty
+func NewFileProductDatabase(path string) (*FileProductDatabase, error) {
+	db := &FileProductDatabase{
+		products: make(map[string]Product),
+	}
+	if path == "" {
+		return db, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read product database %v: %w", path, err)
+	}
+	products := []Product{}
+	if err := json.Unmarshal(data, &products); err != nil {
+		return nil, fmt.Errorf("could not parse product database %v: %w", path, err)
+	}
+
+	for _, product := range products {
+		product.Barcode = Normalize(product.Barcode)
+		if err := Validate(product.Barcode); err != nil {
+			zap.S().Warnw("Skipping product with invalid barcode", "path", path, "error", err)
+			continue
+		}
+		db.products[product.Barcode] = product
+	}
+	zap.S().Infow("Loaded product database", "path", path, "products", len(db.products))
+
+	return db, nil
+}
+
+func (db *FileProductDatabase) LookupProduct(code string) (*Product, error) {
+	product, ok := db.products[code]
+	if !ok {
+		return nil, ErrProductNotFound
+	}
+	return &product, nil
+}
@@ -0,0 +1,273 @@
+package demo
+
+import (
+	"ShoppingList-Backend/internal/pkg/controller"
+	"ShoppingList-Backend/internal/pkg/household"
+	"ShoppingList-Backend/internal/pkg/item"
+	"ShoppingList-Backend/internal/pkg/list"
+	"ShoppingList-Backend/internal/pkg/recipe"
+	"ShoppingList-Backend/internal/pkg/user"
+	"ShoppingList-Backend/pkg/identity"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// Usernames of provisioned demo users start with this, so they can be told apart from other members of the demo group
+	usernamePrefix = "demo-"
+	// Provisioned demo users are deleted by the nightly clean up once they are this old
+	provisionedUserTTL = 24 * time.Hour
+	// No more demo users are provisioned while the demo group has this many members
+	maxDemoUsers = 500
+)
+
+type DemoController struct {
+	identity identity.Provider
+	// Keycloak group of the demo users. Demo users are not provisioned if empty
+	groupID string
+	fixture *Fixture
+
+	itemRepo      *item.ItemRepository
+	listRepo      *list.ListRepository
+	recipeRepo    *recipe.RecipeRepository
+	householdRepo *household.HouseholdRepository
+	userRepo      *user.UserRepository
+
+	users      *user.UserController
+	households *household.HouseholdController
+	items      *item.ItemController
+	lists      *list.ListController
+}
+
+func NewDemoController(identityProvider identity.Provider, groupID string, itemRepo *item.ItemRepository, listRepo *list.ListRepository, recipeRepo *recipe.RecipeRepository,
+	householdRepo *household.HouseholdRepository, userRepo *user.UserRepository, users *user.UserController, households *household.HouseholdController, items *item.ItemController, lists *list.ListController) (*DemoController, error) {
+	fixture, err := DefaultFixture()
+	if err != nil {
+		return nil, fmt.Errorf("could not read demo fixture: %w", err)
+	}
+	return &DemoController{
+		identity:      identityProvider,
+		groupID:       groupID,
+		fixture:       fixture,
+		itemRepo:      itemRepo,
+		listRepo:      listRepo,
+		recipeRepo:    recipeRepo,
+		householdRepo: householdRepo,
+		userRepo:      userRepo,
+		users:         users,
+		households:    households,
+		items:         items,
+		lists:         lists,
+	}, nil
+}
+
+func randomString(length int) (string, error) {
+	randomBytes := make([]byte, length)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(randomBytes), nil
+}
+
+// ProvisionDemoUser creates a demo user with a random username and password, and seeds it with the sample data
+func (c *DemoController) ProvisionDemoUser(ctx context.Context) (*DemoUser, *controller.ControllerError) {
+	if c.groupID == "" {
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("demo users are not enabled"))
+	}
+
+	members, err := c.identity.GetGroupMembers(ctx, c.groupID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get demo users: %w", err))
+	}
+	if len(members) >= maxDemoUsers {
+		return nil, controller.CError(http.StatusServiceUnavailable, fmt.Errorf("there are too many demo users, try again tomorrow"))
+	}
+
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not generate username: %w", err))
+	}
+	username := usernamePrefix + hex.EncodeToString(suffix)
+	password, err := randomString(18)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not generate password: %w", err))
+	}
+
+	demoUser, err := c.identity.CreateUser(ctx, identity.NewUser{
+		Username: username,
+		Email:    username + "@demo.invalid",
+		Password: password,
+		GroupIDs: []string{c.groupID},
+	})
+	if err != nil {
+		if errors.Is(err, identity.ErrUserExists) {
+			return nil, controller.CError(http.StatusConflict, fmt.Errorf("could not create demo user, try again"))
+		}
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not create demo user: %w", err))
+	}
+
+	if err := c.seed(*demoUser); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not seed demo user %v: %w", demoUser.ID, err))
+	}
+
+	return &DemoUser{
+		UserID:   demoUser.ID,
+		Username: username,
+		Password: password,
+	}, nil
+}
+
+// CleanUpDemoUsers resets the data of the demo users to the sample data. Provisioned demo users are deleted once they are old enough
+func (c *DemoController) CleanUpDemoUsers(ctx context.Context, now time.Time) error {
+	if c.groupID == "" {
+		return nil
+	}
+	return cleanUpDemoUsers(ctx, c.identity, c.groupID, now, c.wipe, c.seed, c.forget)
+}
+
+// cleanUpDemoUsers wipes the data of every member of the demo group. Provisioned demo users older than provisionedUserTTL
+// are then forgotten and deleted from the identity provider, and the other members are seeded with the sample data again
+func cleanUpDemoUsers(ctx context.Context, identityProvider identity.Provider, groupID string, now time.Time,
+	wipe func(userID string) error, seed func(demoUser identity.User) error, forget func(userID string) error) error {
+	demoUsers, err := identityProvider.GetGroupMembers(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("could not get users of demo group (id: %v): %w", groupID, err)
+	}
+	for _, demoUser := range demoUsers {
+		if err := wipe(demoUser.ID); err != nil {
+			return fmt.Errorf("could not clean up demo user %v: %w", demoUser.ID, err)
+		}
+
+		if strings.HasPrefix(demoUser.Username, usernamePrefix) && now.Sub(demoUser.CreatedAt) > provisionedUserTTL {
+			// Forgotten first, so if it fails, the user is still in the group and is cleaned up on the next run
+			if err := forget(demoUser.ID); err != nil {
+				return fmt.Errorf("could not delete data of demo user %v: %w", demoUser.ID, err)
+			}
+			if err := identityProvider.DeleteUser(ctx, demoUser.ID); err != nil && !errors.Is(err, identity.ErrUserNotFound) {
+				return fmt.Errorf("could not delete demo user %v: %w", demoUser.ID, err)
+			}
+			zap.S().Infow("Deleted demo user", "userID", demoUser.ID)
+			continue
+		}
+
+		if err := seed(demoUser); err != nil {
+			return fmt.Errorf("could not seed demo user %v: %w", demoUser.ID, err)
+		}
+	}
+	return nil
+}
+
+// wipe deletes the items, lists and recipes the user created
+func (c *DemoController) wipe(userID string) error {
+	if err := c.itemRepo.DeleteItems(userID); err != nil {
+		return fmt.Errorf("error deleting items: %w", err)
+	}
+	if err := c.listRepo.DeleteLists(userID); err != nil {
+		return fmt.Errorf("error deleting lists: %w", err)
+	}
+	if err := c.recipeRepo.DeleteRecipes(userID); err != nil {
+		return fmt.Errorf("error deleting recipes: %w", err)
+	}
+	return nil
+}
+
+// forget deletes the households and profile of a user that is being deleted. The personal access tokens of the user
+// are deleted with the profile, so they stop working with the user
+func (c *DemoController) forget(userID string) error {
+	if err := c.householdRepo.DeleteHouseholds(userID); err != nil {
+		return fmt.Errorf("error deleting households: %w", err)
+	}
+	if err := c.userRepo.DeleteUser(userID); err != nil {
+		return fmt.Errorf("error deleting user: %w", err)
+	}
+	return nil
+}
+
+// seed adds the items and lists of the fixture to the personal household of the user
+func (c *DemoController) seed(demoUser identity.User) error {
+	if cErr := c.users.SyncUser(demoUser.ID, user.Claims{
+		Name:              demoUser.Username,
+		Email:             demoUser.Email,
+		PreferredUsername: demoUser.Username,
+	}); cErr != nil {
+		return cErr.Err
+	}
+	householdID, cErr := c.households.ResolveHousehold(demoUser.ID, "")
+	if cErr != nil {
+		return cErr.Err
+	}
+	appUser := &user.AppUser{ID: demoUser.ID, HouseholdID: householdID}
+
+	createdItems := map[string]item.Item{}
+	createItem := func(name string) (item.Item, error) {
+		if foundItem, ok := createdItems[name]; ok {
+			return foundItem, nil
+		}
+		createdItem, cErr := c.items.CreateItem(appUser, &item.AddItem{Name: name})
+		if cErr != nil {
+			return item.Item{}, cErr.Err
+		}
+		createdItems[name] = *createdItem
+		return *createdItem, nil
+	}
+
+	for _, name := range c.fixture.Items {
+		if _, err := createItem(name); err != nil {
+			return err
+		}
+	}
+
+	for _, fixtureList := range c.fixture.Lists {
+		createdList, cErr := c.lists.CreateList(appUser, &list.AddList{Name: fixtureList.Name})
+		if cErr != nil {
+			return cErr.Err
+		}
+
+		itemQuantities := make([]list.ItemQuantity, 0, len(fixtureList.Items))
+		crossed := map[uuid.UUID]bool{}
+		for _, fixtureItem := range fixtureList.Items {
+			createdItem, err := createItem(fixtureItem.Name)
+			if err != nil {
+				return err
+			}
+			itemQuantities = append(itemQuantities, list.ItemQuantity{
+				ItemID:   createdItem.ID,
+				Quantity: fixtureItem.Quantity,
+				Unit:     fixtureItem.Unit,
+			})
+			if fixtureItem.Crossed {
+				crossed[createdItem.ID] = true
+			}
+		}
+		seededList, cErr := c.lists.MergeItemsIntoList(appUser, createdList.ID, itemQuantities)
+		if cErr != nil {
+			return cErr.Err
+		}
+
+		for _, listItem := range seededList.Items {
+			if !crossed[listItem.ItemID] {
+				continue
+			}
+			if _, cErr := c.lists.UpdateListItem(appUser, seededList.ID, listItem.ID, &list.UpdateListItem{Crossed: true}); cErr != nil {
+				return cErr.Err
+			}
+		}
+
+		if fixtureList.Default {
+			if _, cErr := c.lists.SetDefaultList(appUser, seededList.ID); cErr != nil {
+				return cErr.Err
+			}
+		}
+	}
+	return nil
+}
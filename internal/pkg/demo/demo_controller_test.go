@@ -0,0 +1,123 @@
+package demo
+
+import (
+	"ShoppingList-Backend/pkg/identity"
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+const testGroupID = "demo-group"
+
+func TestCleanUpDemoUsers(t *testing.T) {
+	now := time.Date(2026, 10, 19, 7, 40, 20, 0, time.UTC)
+	provider := identity.NewFakeProvider()
+	// A shared demo account, which is never deleted however old it is
+	provider.AddUser(identity.User{ID: "shared", Username: "demo", CreatedAt: now.Add(-30 * 24 * time.Hour)}, testGroupID)
+	provider.AddUser(identity.User{ID: "expired", Username: usernamePrefix + "expired", CreatedAt: now.Add(-provisionedUserTTL - time.Minute)}, testGroupID)
+	provider.AddUser(identity.User{ID: "fresh", Username: usernamePrefix + "fresh", CreatedAt: now.Add(-time.Hour)}, testGroupID)
+	// Not in the demo group, so it is left alone
+	provider.AddUser(identity.User{ID: "other", Username: usernamePrefix + "other", CreatedAt: now.Add(-30 * 24 * time.Hour)})
+
+	wiped := []string{}
+	seeded := []string{}
+	forgotten := []string{}
+	err := cleanUpDemoUsers(context.Background(), provider, testGroupID, now,
+		func(userID string) error {
+			wiped = append(wiped, userID)
+			return nil
+		},
+		func(demoUser identity.User) error {
+			seeded = append(seeded, demoUser.ID)
+			return nil
+		},
+		func(userID string) error {
+			forgotten = append(forgotten, userID)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("cleanUpDemoUsers returned an error: %v", err)
+	}
+
+	sort.Strings(wiped)
+	if want := []string{"expired", "fresh", "shared"}; !equal(wiped, want) {
+		t.Errorf("wiped %v, want %v", wiped, want)
+	}
+	sort.Strings(seeded)
+	if want := []string{"fresh", "shared"}; !equal(seeded, want) {
+		t.Errorf("seeded %v, want %v", seeded, want)
+	}
+
+	if want := []string{"expired"}; !equal(forgotten, want) {
+		t.Errorf("forgot %v, want %v", forgotten, want)
+	}
+
+	if _, err := provider.GetUser(context.Background(), "expired"); !errors.Is(err, identity.ErrUserNotFound) {
+		t.Errorf("provisioned user older than the TTL was not deleted, got error %v", err)
+	}
+	for _, userID := range []string{"shared", "fresh", "other"} {
+		if _, err := provider.GetUser(context.Background(), userID); err != nil {
+			t.Errorf("user %v was deleted: %v", userID, err)
+		}
+	}
+}
+
+func TestCleanUpDemoUsersStopsWhenWipeFails(t *testing.T) {
+	now := time.Date(2026, 10, 19, 7, 40, 20, 0, time.UTC)
+	provider := identity.NewFakeProvider()
+	provider.AddUser(identity.User{ID: "expired", Username: usernamePrefix + "expired", CreatedAt: now.Add(-2 * provisionedUserTTL)}, testGroupID)
+
+	err := cleanUpDemoUsers(context.Background(), provider, testGroupID, now,
+		func(userID string) error {
+			return errors.New("database is down")
+		},
+		func(demoUser identity.User) error {
+			t.Errorf("user %v was seeded after wiping failed", demoUser.ID)
+			return nil
+		},
+		func(userID string) error {
+			t.Errorf("user %v was forgotten after wiping failed", userID)
+			return nil
+		})
+	if err == nil {
+		t.Fatalf("cleanUpDemoUsers did not return the error of wipe")
+	}
+
+	// The user is kept, so the data is cleaned up on the next run
+	if _, err := provider.GetUser(context.Background(), "expired"); err != nil {
+		t.Errorf("user was deleted although their data was not: %v", err)
+	}
+}
+
+func equal(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCleanUpDemoUsersKeepsUserWhenForgetFails(t *testing.T) {
+	now := time.Date(2026, 10, 19, 7, 40, 20, 0, time.UTC)
+	provider := identity.NewFakeProvider()
+	provider.AddUser(identity.User{ID: "expired", Username: usernamePrefix + "expired", CreatedAt: now.Add(-2 * provisionedUserTTL)}, testGroupID)
+
+	err := cleanUpDemoUsers(context.Background(), provider, testGroupID, now,
+		func(userID string) error { return nil },
+		func(demoUser identity.User) error { return nil },
+		func(userID string) error { return errors.New("database is down") })
+	if err == nil {
+		t.Fatalf("cleanUpDemoUsers did not return the error of forget")
+	}
+
+	// The user is still in the group, so their profile and tokens are deleted on the next run
+	if _, err := provider.GetUser(context.Background(), "expired"); err != nil {
+		t.Errorf("user was deleted although their profile was not: %v", err)
+	}
+}
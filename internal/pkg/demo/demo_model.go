@@ -0,0 +1,46 @@
+package demo
+
+import (
+	_ "embed"
+	"encoding/json"
+)
+
+// DemoUser is a provisioned demo user, with the credentials to log in with
+type DemoUser struct {
+	UserID   string `json:"userId"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Fixture is the sample data demo users are seeded with
+type Fixture struct {
+	// Items in the catalog, also those not on any list
+	Items []string      `json:"items"`
+	Lists []FixtureList `json:"lists"`
+}
+
+type FixtureList struct {
+	Name string `json:"name"`
+	// At most one list should be the default list
+	Default bool              `json:"default"`
+	Items   []FixtureListItem `json:"items"`
+}
+
+type FixtureListItem struct {
+	Name     string  `json:"name"`
+	Quantity float64 `json:"quantity"`
+	Unit     string  `json:"unit"`
+	Crossed  bool    `json:"crossed"`
+}
+
+//go:embed fixture.json
+var fixtureJSON []byte
+
+// DefaultFixture returns the embedded sample data
+func DefaultFixture() (*Fixture, error) {
+	fixture := &Fixture{}
+	if err := json.Unmarshal(fixtureJSON, fixture); err != nil {
+		return nil, err
+	}
+	return fixture, nil
+}
@@ -0,0 +1,198 @@
+package household
+
+import (
+	"ShoppingList-Backend/internal/pkg/controller"
+	"ShoppingList-Backend/internal/pkg/user"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type HouseholdController struct {
+	householdRepo *HouseholdRepository
+	userRepo      *user.UserRepository
+}
+
+func NewHouseholdController(householdRepo *HouseholdRepository, userRepo *user.UserRepository) *HouseholdController {
+	return &HouseholdController{
+		householdRepo: householdRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// withProfiles sets the profiles of the members
+func (c *HouseholdController) withProfiles(members []Member) error {
+	userIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.UserID)
+	}
+	profiles, err := c.userRepo.GetProfiles(userIDs)
+	if err != nil {
+		return err
+	}
+	for i := range members {
+		if profile, ok := profiles[members[i].UserID]; ok {
+			members[i].User = &profile
+		}
+	}
+	return nil
+}
+
+// ResolveHousehold returns the ID of the household the request acts on. If householdID is empty, it is the personal household of the user
+func (c *HouseholdController) ResolveHousehold(userID string, householdID string) (uuid.UUID, *controller.ControllerError) {
+	if householdID == "" {
+		household, err := c.householdRepo.GetPersonalHousehold(userID)
+		if err != nil {
+			return uuid.Nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get personal household: %w", err))
+		}
+		return household.ID, nil
+	}
+
+	id, err := uuid.Parse(householdID)
+	if err != nil {
+		return uuid.Nil, controller.CError(http.StatusBadRequest, fmt.Errorf("could not parse household id %v: %w", householdID, err))
+	}
+	if _, err := c.householdRepo.GetMember(id, userID); err != nil {
+		return uuid.Nil, controller.CError(http.StatusForbidden, fmt.Errorf("not a member of household with ID %v", id))
+	}
+	return id, nil
+}
+
+func (c *HouseholdController) GetHouseholds(user *user.AppUser) ([]Household, *controller.ControllerError) {
+	// Make sure the personal household exists, also if no household scoped request has been made yet
+	if _, err := c.householdRepo.GetPersonalHousehold(user.ID); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get personal household: %w", err))
+	}
+
+	households, err := c.householdRepo.GetHouseholds(user.ID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get households: %w", err))
+	}
+	return households, nil
+}
+
+func (c *HouseholdController) CreateHousehold(user *user.AppUser, addHousehold *AddHousehold) (*Household, *controller.ControllerError) {
+	name := strings.TrimSpace(addHousehold.Name)
+	if name == "" {
+		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("household name must not be empty"))
+	}
+
+	householdToCreate := Household{
+		ID:        uuid.New(),
+		Name:      name,
+		CreatedBy: user.ID,
+	}
+	householdID, err := c.householdRepo.CreateHousehold(householdToCreate)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not create household: %w", err))
+	}
+
+	createdHousehold, err := c.householdRepo.GetHousehold(householdID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get created household with ID %v: %w", householdID, err))
+	}
+	return &createdHousehold, nil
+}
+
+// getMembership returns the household and the membership of the user, which is required to see anything about the household
+func (c *HouseholdController) getMembership(user *user.AppUser, householdID uuid.UUID) (Household, Member, *controller.ControllerError) {
+	household, err := c.householdRepo.GetHousehold(householdID)
+	if err != nil {
+		return household, Member{}, controller.CError(http.StatusNotFound, fmt.Errorf("household with ID %v not found", householdID))
+	}
+	member, err := c.householdRepo.GetMember(householdID, user.ID)
+	if err != nil {
+		return household, member, controller.CError(http.StatusNotFound, fmt.Errorf("household with ID %v not found", householdID))
+	}
+	return household, member, nil
+}
+
+func (c *HouseholdController) GetMembers(user *user.AppUser, householdID uuid.UUID) ([]Member, *controller.ControllerError) {
+	if _, _, cErr := c.getMembership(user, householdID); cErr != nil {
+		return nil, cErr
+	}
+
+	members, err := c.householdRepo.GetMembers(householdID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get members of household with ID %v: %w", householdID, err))
+	}
+	if err := c.withProfiles(members); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get profiles of members of household with ID %v: %w", householdID, err))
+	}
+	return members, nil
+}
+
+// SetMember adds a user to the household, or changes their role. Only owners can manage members
+func (c *HouseholdController) SetMember(user *user.AppUser, householdID uuid.UUID, addMember *AddMember) (*Member, *controller.ControllerError) {
+	household, member, cErr := c.getMembership(user, householdID)
+	if cErr != nil {
+		return nil, cErr
+	}
+	if member.Role != RoleOwner {
+		return nil, controller.CError(http.StatusForbidden, fmt.Errorf("only owners can manage the members of household with ID %v", householdID))
+	}
+	if household.Personal {
+		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("personal households cannot be shared"))
+	}
+
+	role := addMember.Role
+	if role == "" {
+		role = RoleMember
+	}
+	if role != RoleOwner && role != RoleMember {
+		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("role must be %v or %v", RoleOwner, RoleMember))
+	}
+	if strings.TrimSpace(addMember.UserID) == "" {
+		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("userId must not be empty"))
+	}
+	if addMember.UserID == user.ID && role != RoleOwner {
+		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("owners cannot demote themselves"))
+	}
+
+	memberToSet := Member{
+		HouseholdID: householdID,
+		UserID:      strings.TrimSpace(addMember.UserID),
+		Role:        role,
+	}
+	if err := c.householdRepo.SetMember(memberToSet); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not add member to household with ID %v: %w", householdID, err))
+	}
+
+	updatedMember, err := c.householdRepo.GetMember(householdID, memberToSet.UserID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get member of household with ID %v: %w", householdID, err))
+	}
+	updatedMembers := []Member{updatedMember}
+	if err := c.withProfiles(updatedMembers); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get profile of member of household with ID %v: %w", householdID, err))
+	}
+	return &updatedMembers[0], nil
+}
+
+// RemoveMember removes a user from the household. Owners can remove anyone else, and members can leave
+func (c *HouseholdController) RemoveMember(user *user.AppUser, householdID uuid.UUID, userID string) *controller.ControllerError {
+	household, member, cErr := c.getMembership(user, householdID)
+	if cErr != nil {
+		return cErr
+	}
+	if household.Personal {
+		return controller.CError(http.StatusBadRequest, fmt.Errorf("personal households cannot be left"))
+	}
+	if userID == user.ID && member.Role == RoleOwner {
+		return controller.CError(http.StatusBadRequest, fmt.Errorf("owners cannot leave their household"))
+	}
+	if userID != user.ID && member.Role != RoleOwner {
+		return controller.CError(http.StatusForbidden, fmt.Errorf("only owners can manage the members of household with ID %v", householdID))
+	}
+
+	memberToRemove, err := c.householdRepo.GetMember(householdID, userID)
+	if err != nil {
+		return controller.CError(http.StatusNotFound, fmt.Errorf("member %v of household with ID %v not found", userID, householdID))
+	}
+	if err := c.householdRepo.RemoveMember(memberToRemove); err != nil {
+		return controller.CError(http.StatusInternalServerError, fmt.Errorf("could not remove member %v from household with ID %v: %w", userID, householdID, err))
+	}
+	return nil
+}
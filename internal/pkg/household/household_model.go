@@ -0,0 +1,44 @@
+package household
+
+import (
+	"ShoppingList-Backend/internal/pkg/user"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RoleOwner  = "owner"
+	RoleMember = "member"
+)
+
+type Household struct {
+	ID        uuid.UUID  `db:"id" json:"id"`
+	CreatedAt time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt *time.Time `db:"updated_at" json:"updatedAt"`
+
+	Name string `db:"name" json:"name"`
+	// Personal households are created for every user, and are used when no household is chosen
+	Personal  bool   `db:"personal" json:"personal"`
+	CreatedBy string `db:"created_by" json:"createdBy"`
+}
+
+type Member struct {
+	HouseholdID uuid.UUID `db:"household_id" json:"householdId"`
+	UserID      string    `db:"user_id" json:"userId"`
+	Role        string    `db:"role" json:"role"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+
+	// User is nil if the member has not used the app yet
+	User *user.Profile `db:"-" json:"user"`
+}
+
+type AddHousehold struct {
+	Name string `json:"name"`
+}
+
+type AddMember struct {
+	UserID string `json:"userId"`
+	// owner or member, defaults to member
+	Role string `json:"role"`
+}
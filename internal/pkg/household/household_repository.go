@@ -0,0 +1,145 @@
+package household
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type HouseholdRepository struct {
+	DB *sqlx.DB
+}
+
+// GetHouseholds returns the households the user is a member of
+func (q *HouseholdRepository) GetHouseholds(userID string) ([]Household, error) {
+	households := []Household{}
+	query := `SELECT households.* FROM households
+		JOIN household_members ON household_members.household_id = households.id
+		WHERE household_members.user_id = $1
+		ORDER BY households.personal DESC, households.created_at ASC`
+	err := q.DB.Select(&households, query, userID)
+	return households, err
+}
+
+func (q *HouseholdRepository) GetHousehold(id uuid.UUID) (Household, error) {
+	household := Household{}
+	query := `SELECT * FROM households WHERE id = $1`
+	err := q.DB.Get(&household, query, id)
+	return household, err
+}
+
+// GetPersonalHousehold returns the personal household of the user, creating it if the user does not have one yet
+func (q *HouseholdRepository) GetPersonalHousehold(userID string) (Household, error) {
+	household := Household{}
+	fetchQuery := `SELECT * FROM households WHERE personal AND created_by = $1`
+	err := q.DB.Get(&household, fetchQuery, userID)
+	if err == nil || !errors.Is(err, sql.ErrNoRows) {
+		return household, err
+	}
+
+	tx, err := q.DB.Beginx()
+	if err != nil {
+		return household, err
+	}
+	defer tx.Rollback()
+
+	householdID := uuid.New()
+	insertQuery := `INSERT INTO households (id, name, personal, created_by) VALUES ($1, 'Personal', TRUE, $2) ON CONFLICT DO NOTHING`
+	result, err := tx.Exec(insertQuery, householdID, userID)
+	if err != nil {
+		return household, err
+	}
+	// Another request created it between the lookup and the insert
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		tx.Rollback()
+		err = q.DB.Get(&household, fetchQuery, userID)
+		return household, err
+	}
+
+	memberQuery := `INSERT INTO household_members (household_id, user_id, role) VALUES ($1, $2, $3)`
+	if _, err := tx.Exec(memberQuery, householdID, userID, RoleOwner); err != nil {
+		return household, err
+	}
+	if err := tx.Commit(); err != nil {
+		return household, err
+	}
+
+	err = q.DB.Get(&household, fetchQuery, userID)
+	return household, err
+}
+
+// CreateHousehold creates the household, with the creator as owner
+func (q *HouseholdRepository) CreateHousehold(household Household) (uuid.UUID, error) {
+	tx, err := q.DB.Beginx()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO households (id, name, created_by) VALUES ($1, $2, $3)`
+	if _, err := tx.Exec(query, household.ID, household.Name, household.CreatedBy); err != nil {
+		return uuid.Nil, err
+	}
+	memberQuery := `INSERT INTO household_members (household_id, user_id, role) VALUES ($1, $2, $3)`
+	if _, err := tx.Exec(memberQuery, household.ID, household.CreatedBy, RoleOwner); err != nil {
+		return uuid.Nil, err
+	}
+
+	return household.ID, tx.Commit()
+}
+
+func (q *HouseholdRepository) GetMembers(householdID uuid.UUID) ([]Member, error) {
+	members := []Member{}
+	query := `SELECT * FROM household_members WHERE household_id = $1 ORDER BY created_at ASC`
+	err := q.DB.Select(&members, query, householdID)
+	return members, err
+}
+
+func (q *HouseholdRepository) GetMember(householdID uuid.UUID, userID string) (Member, error) {
+	member := Member{}
+	query := `SELECT * FROM household_members WHERE household_id = $1 AND user_id = $2`
+	err := q.DB.Get(&member, query, householdID, userID)
+	return member, err
+}
+
+// SetMember adds the user to the household, or changes the role if the user is already a member
+func (q *HouseholdRepository) SetMember(member Member) error {
+	query := `INSERT INTO household_members (household_id, user_id, role) VALUES ($1, $2, $3)
+		ON CONFLICT (household_id, user_id) DO UPDATE SET role = $3`
+	_, err := q.DB.Exec(query, member.HouseholdID, member.UserID, member.Role)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (q *HouseholdRepository) RemoveMember(member Member) error {
+	query := `DELETE FROM household_members WHERE household_id = $1 AND user_id = $2`
+	_, err := q.DB.Exec(query, member.HouseholdID, member.UserID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteHouseholds deletes the households the user created, with everything in them, and removes the user from the other households
+func (q *HouseholdRepository) DeleteHouseholds(userID string) error {
+	tx, err := q.DB.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM households WHERE created_by = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM household_members WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM default_lists WHERE app_user_id = $1`, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -1,8 +1,11 @@
 package item
 
 import (
+	"ShoppingList-Backend/internal/pkg/barcode"
 	"ShoppingList-Backend/internal/pkg/controller"
 	"ShoppingList-Backend/internal/pkg/user"
+	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -11,42 +14,101 @@ import (
 
 type ItemController struct {
 	itemRepo *ItemRepository
+	userRepo *user.UserRepository
+	products barcode.ProductDatabase
 }
 
-func NewItemController(itemRepo *ItemRepository) *ItemController {
+func NewItemController(itemRepo *ItemRepository, userRepo *user.UserRepository, products barcode.ProductDatabase) *ItemController {
 	return &ItemController{
 		itemRepo: itemRepo,
+		userRepo: userRepo,
+		products: products,
 	}
 }
 
+// normalizeBarcode normalizes and validates the barcode. An empty barcode is returned as nil
+func normalizeBarcode(code *string) (*string, error) {
+	if code == nil {
+		return nil, nil
+	}
+	normalized := barcode.Normalize(*code)
+	if normalized == "" {
+		return nil, nil
+	}
+	if err := barcode.Validate(normalized); err != nil {
+		return nil, err
+	}
+	return &normalized, nil
+}
+
+func itemExistsError(itemID uuid.UUID, err error) *controller.ControllerError {
+	if errors.Is(err, ErrItemExists) || errors.Is(err, ErrBarcodeExists) {
+		return controller.CError(http.StatusConflict, fmt.Errorf("could not save item ID %v: %w", itemID, err))
+	}
+	return controller.CError(http.StatusInternalServerError, fmt.Errorf("could not save item ID %v: %w", itemID, err))
+}
+
 func (c *ItemController) GetItems(user *user.AppUser) ([]Item, *controller.ControllerError) {
 	if user == nil {
 		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("nil user"))
 	}
-	items, err := c.itemRepo.GetItems(user.ID)
+	items, err := c.itemRepo.GetItems(user.HouseholdID)
 	if err != nil {
 		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("items not found: %w", err))
 	}
 	return items, nil
 }
 
+func (c *ItemController) GetItemCreator(user *user.AppUser, itemID uuid.UUID) (*ItemCreator, *controller.ControllerError) {
+	foundItem, err := c.itemRepo.GetItem(itemID, user.HouseholdID)
+	if err != nil {
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("item with ID %v not found", itemID))
+	}
+
+	itemCreator := &ItemCreator{
+		ItemID:    foundItem.ID,
+		CreatedBy: foundItem.OwnerID,
+		CreatedAt: foundItem.CreatedAt,
+	}
+	profiles, err := c.userRepo.GetProfiles([]string{foundItem.OwnerID})
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get profile of creator of item with ID %v: %w", itemID, err))
+	}
+	if profile, ok := profiles[foundItem.OwnerID]; ok {
+		itemCreator.Creator = &profile
+	}
+	return itemCreator, nil
+}
+
 func (c *ItemController) CreateItem(user *user.AppUser, addItem *AddItem) (*Item, *controller.ControllerError) {
 	if user == nil || addItem == nil {
 		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("nil params: %v and %v", user, addItem))
 	}
 
+	name := NormalizeName(addItem.Name)
+	if name == "" {
+		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("item name must not be empty"))
+	}
+
+	code, err := normalizeBarcode(addItem.Barcode)
+	if err != nil {
+		return nil, controller.CError(http.StatusBadRequest, err)
+	}
+
 	itemToCreate := &Item{
-		ID:      uuid.New(),
-		Name:    addItem.Name,
-		OwnerID: user.ID,
+		ID:          uuid.New(),
+		Name:        name,
+		OwnerID:     user.ID,
+		HouseholdID: user.HouseholdID,
+		Barcode:     code,
 	}
 
 	itemId, err := c.itemRepo.CreateItem(itemToCreate)
 	if err != nil {
-		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not create item: %w", err))
+		return nil, itemExistsError(itemToCreate.ID, err)
 	}
 
-	createdItem, err := c.itemRepo.GetItem(itemId)
+	createdItem, err := c.itemRepo.GetItem(itemId, user.HouseholdID)
 	if err != nil {
 		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get created item: %w", err))
 	}
@@ -59,18 +121,28 @@ func (c *ItemController) UpdateItem(user *user.AppUser, itemID uuid.UUID, update
 		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("nil params: %v and %v", user, updateItem))
 	}
 
-	foundItem, err := c.itemRepo.GetItem(itemID)
+	foundItem, err := c.itemRepo.GetItem(itemID, user.HouseholdID)
 	if err != nil {
-		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("item with ID %v not found: %w", itemID, err))
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("item with ID %v not found", itemID))
 	}
 
-	foundItem.Name = updateItem.Name
+	foundItem.Name = NormalizeName(updateItem.Name)
+	if foundItem.Name == "" {
+		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("item name must not be empty"))
+	}
+	if updateItem.Barcode != nil {
+		code, err := normalizeBarcode(updateItem.Barcode)
+		if err != nil {
+			return nil, controller.CError(http.StatusBadRequest, err)
+		}
+		foundItem.Barcode = code
+	}
 
 	if err := c.itemRepo.UpdateItem(&foundItem); err != nil {
-		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not update item ID %v: %w", itemID, err))
+		return nil, itemExistsError(itemID, err)
 	}
 
-	updatedItem, err := c.itemRepo.GetItem(itemID)
+	updatedItem, err := c.itemRepo.GetItem(itemID, user.HouseholdID)
 	if err != nil {
 		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get updated item with ID %v: %w", itemID, err))
 	}
@@ -80,18 +152,100 @@ func (c *ItemController) UpdateItem(user *user.AppUser, itemID uuid.UUID, update
 
 func (c *ItemController) DeleteItem(user *user.AppUser, itemID uuid.UUID) (bool, *controller.ControllerError) {
 
-	foundItem, err := c.itemRepo.GetItem(itemID)
+	foundItem, err := c.itemRepo.GetItem(itemID, user.HouseholdID)
 	if err != nil {
 		return false, controller.CError(http.StatusNotFound, fmt.Errorf("item with ID %v not found: %w", itemID, err))
 	}
 
-	if foundItem.OwnerID != user.ID {
-		return false, controller.CError(http.StatusNotFound, fmt.Errorf("item with ID %v not found", itemID))
-	}
-
 	if err := c.itemRepo.DeleteItem(&foundItem); err != nil {
 		return false, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not delete item with ID %v: %w", itemID, err))
 	}
 
 	return true, nil
 }
+
+func (c *ItemController) MergeItems(user *user.AppUser, itemID uuid.UUID, mergeItems *MergeItems) (*Item, *controller.ControllerError) {
+	if user == nil || mergeItems == nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("nil params: %v and %v", user, mergeItems))
+	}
+
+	targetItem, err := c.itemRepo.GetItem(itemID, user.HouseholdID)
+	if err != nil {
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("item with ID %v not found", itemID))
+	}
+
+	sourceIDs := make([]uuid.UUID, 0, len(mergeItems.ItemIDs))
+	for _, sourceID := range mergeItems.ItemIDs {
+		if sourceID == itemID {
+			continue
+		}
+		if _, err := c.itemRepo.GetItem(sourceID, user.HouseholdID); err != nil {
+			return nil, controller.CError(http.StatusNotFound, fmt.Errorf("item with ID %v not found", sourceID))
+		}
+		sourceIDs = append(sourceIDs, sourceID)
+	}
+	if len(sourceIDs) == 0 {
+		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("no items to merge into item ID %v", itemID))
+	}
+
+	if err := c.itemRepo.MergeItems(&targetItem, sourceIDs); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not merge items into item ID %v: %w", itemID, err))
+	}
+
+	mergedItem, err := c.itemRepo.GetItem(itemID, user.HouseholdID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get merged item with ID %v: %w", itemID, err))
+	}
+
+	return &mergedItem, nil
+}
+
+// ResolveBarcode finds the item with the barcode in the user's catalog. If there is none, the product is looked up
+// in the product database, and added to the catalog
+func (c *ItemController) ResolveBarcode(user *user.AppUser, addBarcode *AddBarcode) (*Item, *controller.ControllerError) {
+	code := barcode.Normalize(addBarcode.Barcode)
+	if err := barcode.Validate(code); err != nil {
+		return nil, controller.CError(http.StatusBadRequest, err)
+	}
+
+	foundItem, err := c.itemRepo.GetItemByBarcode(user.HouseholdID, code)
+	if err == nil {
+		return &foundItem, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get item with barcode %v: %w", code, err))
+	}
+
+	product, err := c.products.LookupProduct(code)
+	if err != nil {
+		if errors.Is(err, barcode.ErrProductNotFound) {
+			return nil, controller.CError(http.StatusNotFound, fmt.Errorf("no product with barcode %v", code))
+		}
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not look up barcode %v: %w", code, err))
+	}
+
+	// If the catalog already has an item with the name of the product, that item is used
+	itemToCreate := &Item{
+		ID:          uuid.New(),
+		Name:        product.Name,
+		OwnerID:     user.ID,
+		HouseholdID: user.HouseholdID,
+	}
+	itemID, err := c.itemRepo.CreateItem(itemToCreate)
+	if err != nil {
+		return nil, itemExistsError(itemToCreate.ID, err)
+	}
+
+	createdItem, err := c.itemRepo.GetItem(itemID, user.HouseholdID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get created item: %w", err))
+	}
+	if createdItem.Barcode == nil {
+		createdItem.Barcode = &code
+		if err := c.itemRepo.UpdateItem(&createdItem); err != nil {
+			return nil, itemExistsError(itemID, err)
+		}
+	}
+
+	return &createdItem, nil
+}
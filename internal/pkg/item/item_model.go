@@ -1,6 +1,8 @@
 package item
 
 import (
+	"ShoppingList-Backend/internal/pkg/user"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,11 +13,39 @@ type Item struct {
 	CreatedAt time.Time  `db:"created_at" json:"createdAt"`
 	UpdatedAt *time.Time `db:"updated_at" json:"updatedAt"`
 	DeletedAt *time.Time `db:"deleted_at" json:"deletedAt"`
-	OwnerID   string     `db:"owner_id" json:"ownerId"`
+	// OwnerID is the user that created the item
+	OwnerID     string    `db:"owner_id" json:"ownerId"`
+	HouseholdID uuid.UUID `db:"household_id" json:"householdId"`
 
-	Name string `db:"name" json:"name"`
+	Name    string  `db:"name" json:"name"`
+	Barcode *string `db:"barcode" json:"barcode"`
+}
+
+// ItemCreator tells which member of the household added the item to the catalog, and when
+type ItemCreator struct {
+	ItemID    uuid.UUID `json:"itemId"`
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Creator is nil if the profile of the user is not known
+	Creator *user.Profile `json:"creator"`
 }
 
 type AddItem struct {
 	Name string `json:"name"`
+	// EAN-8, EAN-13 or UPC-A barcode. When updating, it is left unchanged if not set, and removed if empty
+	Barcode *string `json:"barcode"`
+}
+
+type AddBarcode struct {
+	Barcode string `json:"barcode"`
+}
+
+type MergeItems struct {
+	ItemIDs []uuid.UUID `json:"itemIds"`
+}
+
+// NormalizeName trims the name and collapses inner whitespace, so "milk " and "milk" are stored the same way.
+// Names are compared case-insensitively by the database.
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
 }
@@ -1,20 +1,42 @@
 package item
 
 import (
+	"errors"
+
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
+// ErrItemExists is returned when a household already has a (non-deleted) item with the same name
+var ErrItemExists = errors.New("item with the same name already exists")
+
+// ErrBarcodeExists is returned when a household already has a (non-deleted) item with the same barcode
+var ErrBarcodeExists = errors.New("item with the same barcode already exists")
+
 type ItemRepository struct {
 	DB *sqlx.DB
 }
 
-func (q *ItemRepository) GetItems(ownerID string) ([]Item, error) {
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+func uniqueViolationError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Constraint == "items_household_id_barcode_key" {
+		return ErrBarcodeExists
+	}
+	return ErrItemExists
+}
+
+func (q *ItemRepository) GetItems(householdID uuid.UUID) ([]Item, error) {
 	items := []Item{}
 
-	query := `SELECT * FROM items WHERE owner_id = $1 AND deleted_at IS NULL`
+	query := `SELECT * FROM items WHERE household_id = $1 AND deleted_at IS NULL`
 
-	err := q.DB.Select(&items, query, ownerID)
+	err := q.DB.Select(&items, query, householdID)
 
 	if err != nil {
 		return items, err
@@ -32,12 +54,13 @@ func (q *ItemRepository) DeleteItems(ownerID string) error {
 	return nil
 }
 
-func (q *ItemRepository) GetItem(id uuid.UUID) (Item, error) {
+// GetItem returns the item, if it is in the catalog of the household
+func (q *ItemRepository) GetItem(id uuid.UUID, householdID uuid.UUID) (Item, error) {
 	item := Item{}
 
-	query := `SELECT * FROM items WHERE id = $1 AND deleted_at IS NULL`
+	query := `SELECT * FROM items WHERE id = $1 AND household_id = $2 AND deleted_at IS NULL`
 
-	err := q.DB.Get(&item, query, id)
+	err := q.DB.Get(&item, query, id, householdID)
 	if err != nil {
 		return item, err
 	}
@@ -45,28 +68,60 @@ func (q *ItemRepository) GetItem(id uuid.UUID) (Item, error) {
 	return item, err
 }
 
+func (q *ItemRepository) getItemByName(name string, householdID uuid.UUID) (Item, error) {
+	item := Item{}
+	query := `SELECT * FROM items WHERE lower(name) = lower($1) AND household_id = $2 AND deleted_at IS NULL`
+	err := q.DB.Get(&item, query, name, householdID)
+	return item, err
+}
+
+func (q *ItemRepository) GetItemByBarcode(householdID uuid.UUID, barcode string) (Item, error) {
+	item := Item{}
+	query := `SELECT * FROM items WHERE barcode = $1 AND household_id = $2 AND deleted_at IS NULL`
+	err := q.DB.Get(&item, query, barcode, householdID)
+	return item, err
+}
+
+// CreateItem creates the item, or returns the ID of the household's existing item with the same normalized name
 func (q *ItemRepository) CreateItem(item *Item) (uuid.UUID, error) {
-	existingItem := Item{}
-	fetchQuery := `SELECT * FROM items WHERE name = $1 AND owner_id = $2`
-	err := q.DB.Get(&existingItem, fetchQuery, item.Name, item.OwnerID)
+	item.Name = NormalizeName(item.Name)
+
+	existingItem, err := q.getItemByName(item.Name, item.HouseholdID)
 	if err == nil {
 		return existingItem.ID, nil
 	}
 
-	query := `INSERT INTO items (id, name, owner_id) VALUES ($1, $2, $3)`
+	query := `INSERT INTO items (id, name, owner_id, household_id, barcode) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (household_id, lower(name)) WHERE deleted_at IS NULL DO NOTHING`
 
-	_, err = q.DB.Exec(query, item.ID, item.Name, item.OwnerID)
+	result, err := q.DB.Exec(query, item.ID, item.Name, item.OwnerID, item.HouseholdID, item.Barcode)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return uuid.Nil, uniqueViolationError(err)
+		}
 		return uuid.Nil, err
 	}
 
+	// Someone else created the item between the lookup and the insert
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		existingItem, err := q.getItemByName(item.Name, item.HouseholdID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return existingItem.ID, nil
+	}
+
 	return item.ID, nil
 }
 
 func (q *ItemRepository) UpdateItem(item *Item) error {
-	query := `UPDATE items SET updated_at = NOW(), name = $2 WHERE id = $1`
-	_, err := q.DB.Exec(query, item.ID, item.Name)
+	item.Name = NormalizeName(item.Name)
+	query := `UPDATE items SET updated_at = NOW(), name = $2, barcode = $3 WHERE id = $1`
+	_, err := q.DB.Exec(query, item.ID, item.Name, item.Barcode)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return uniqueViolationError(err)
+		}
 		return err
 	}
 	return nil
@@ -80,3 +135,63 @@ func (q *ItemRepository) DeleteItem(item *Item) error {
 	}
 	return nil
 }
+
+// MergeItems repoints everything that references the source items to the target item, and soft-deletes the source items.
+// The pantry stock of the source items is added to the stock of the target item
+func (q *ItemRepository) MergeItems(target *Item, sourceIDs []uuid.UUID) error {
+	tx, err := q.DB.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	repointQueries := []string{
+		`UPDATE list_item SET item_id = ?, updated_at = NOW() WHERE item_id IN (?)`,
+		`UPDATE purchases SET item_id = ? WHERE item_id IN (?)`,
+		`UPDATE prices SET item_id = ? WHERE item_id IN (?)`,
+		`UPDATE recipe_ingredients SET item_id = ? WHERE item_id IN (?)`,
+		`UPDATE recurring_items SET item_id = ?, updated_at = NOW() WHERE item_id IN (?)`,
+	}
+	for _, repointQuery := range repointQueries {
+		query, args, err := sqlx.In(repointQuery, target.ID, sourceIDs)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(tx.Rebind(query), args...); err != nil {
+			return err
+		}
+	}
+
+	// A household has one pantry row per item, so the stock is summed into the row of the target item.
+	// The unit of the target row is kept if it has one
+	query, args, err := sqlx.In(`INSERT INTO pantry_items (household_id, item_id, quantity, unit, min_quantity)
+		SELECT household_id, ?, SUM(quantity), MAX(unit), MAX(min_quantity) FROM pantry_items WHERE item_id IN (?) GROUP BY household_id
+		ON CONFLICT (household_id, item_id) DO UPDATE SET updated_at = NOW(),
+			quantity = pantry_items.quantity + EXCLUDED.quantity,
+			unit = COALESCE(NULLIF(pantry_items.unit, ''), EXCLUDED.unit),
+			min_quantity = COALESCE(pantry_items.min_quantity, EXCLUDED.min_quantity)`, target.ID, sourceIDs)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(tx.Rebind(query), args...); err != nil {
+		return err
+	}
+
+	query, args, err = sqlx.In(`DELETE FROM pantry_items WHERE item_id IN (?)`, sourceIDs)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(tx.Rebind(query), args...); err != nil {
+		return err
+	}
+
+	query, args, err = sqlx.In(`UPDATE items SET deleted_at = NOW() WHERE household_id = ? AND id IN (?)`, target.HouseholdID, sourceIDs)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(tx.Rebind(query), args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
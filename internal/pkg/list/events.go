@@ -5,4 +5,6 @@ const (
 	EventListItemsAdded   = "LIST_ITEMS_ADDED"
 	EventListItemsUpdated = "LIST_ITEMS_UPDATED"
 	EventListItemsRemoved = "LIST_ITEMS_REMOVED"
+	// Sent when the estimated cost of a list goes above its budget
+	EventListBudgetExceeded = "LIST_BUDGET_EXCEEDED"
 )
@@ -3,24 +3,103 @@ package list
 import (
 	"ShoppingList-Backend/internal/pkg/controller"
 	"ShoppingList-Backend/internal/pkg/item"
+	"ShoppingList-Backend/internal/pkg/unit"
 	"ShoppingList-Backend/internal/pkg/user"
+	"ShoppingList-Backend/pkg/events"
 	"database/sql"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
+// Stock keeps track of what is at home. Items are added to the stock when they are bought
+type Stock interface {
+	AddStock(householdID uuid.UUID, itemID uuid.UUID, quantity float64, unit string) error
+}
+
+// Estimator estimates the cost of list items, in minor units of the currency, preferring the prices at the store.
+// Items without a price in the currency are not included
+type Estimator interface {
+	EstimateCost(householdID uuid.UUID, listItems []ListItem, currency string, store string) (int64, error)
+}
+
+// UserPreferences returns the preferences of a user
+type UserPreferences interface {
+	GetPreferences(userID string) (user.Preferences, error)
+}
+
 type ListController struct {
-	itemRepo *item.ItemRepository
-	listRepo *ListRepository
+	itemRepo    *item.ItemRepository
+	listRepo    *ListRepository
+	events      events.Publisher
+	stock       Stock
+	estimator   Estimator
+	preferences UserPreferences
 }
 
-func NewListController(itemRepo *item.ItemRepository, listRepo *ListRepository) *ListController {
+func NewListController(itemRepo *item.ItemRepository, listRepo *ListRepository, events events.Publisher, stock Stock, estimator Estimator, preferences UserPreferences) *ListController {
 	return &ListController{
-		itemRepo: itemRepo,
-		listRepo: listRepo,
+		itemRepo:    itemRepo,
+		listRepo:    listRepo,
+		events:      events,
+		stock:       stock,
+		estimator:   estimator,
+		preferences: preferences,
+	}
+}
+
+// preferencesOf returns the preferences of the user, or the defaults if they cannot be read
+func (c *ListController) preferencesOf(appUser *user.AppUser) user.Preferences {
+	preferences, err := c.preferences.GetPreferences(appUser.ID)
+	if err != nil {
+		zap.S().Warnw("Could not get preferences, using defaults", "userID", appUser.ID, "error", err)
+		return user.DefaultPreferences()
+	}
+	return preferences
+}
+
+func (c *ListController) publish(list List, eventType string, eventData interface{}) {
+	c.events.Publish(events.Event{EventType: eventType, EventData: eventData}, events.HouseholdRoom(list.HouseholdID))
+}
+
+// checkBudget compares the estimated cost of the list to its budget, and sends an event when the budget is first exceeded.
+// The cost is estimated with the preferred store of the user, like the costs of the list are shown to them.
+// Errors are only logged, as the list items have already been changed
+func (c *ListController) checkBudget(user *user.AppUser, listID uuid.UUID) {
+	foundList, err := c.listRepo.GetList(listID, user)
+	if err != nil {
+		zap.S().Warnw("Could not get list to check budget", "listID", listID, "error", err)
+		return
+	}
+	if foundList.BudgetAmount == nil || foundList.BudgetCurrency == nil {
+		return
+	}
+
+	estimated, err := c.estimator.EstimateCost(foundList.HouseholdID, foundList.Items, *foundList.BudgetCurrency, c.preferencesOf(user).PreferredStore)
+	if err != nil {
+		zap.S().Warnw("Could not estimate list cost", "listID", listID, "error", err)
+		return
+	}
+
+	exceeded := estimated > *foundList.BudgetAmount
+	if exceeded == foundList.BudgetExceeded {
+		return
+	}
+	if err := c.listRepo.SetBudgetExceeded(foundList, exceeded); err != nil {
+		zap.S().Warnw("Could not update list budget", "listID", listID, "error", err)
+		return
+	}
+	if exceeded {
+		c.publish(foundList, EventListBudgetExceeded, BudgetExceeded{
+			ListID:    foundList.ID,
+			Budget:    *foundList.BudgetAmount,
+			Estimated: estimated,
+			Currency:  *foundList.BudgetCurrency,
+		})
 	}
 }
 
@@ -30,9 +109,24 @@ func (c *ListController) GetLists(user *user.AppUser) ([]List, *controller.Contr
 		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("lists not found: %w", err))
 	}
 
+	sortOrder := c.preferencesOf(user).SortOrder
+	for _, list := range lists {
+		SortListItems(list.Items, sortOrder)
+	}
+
 	return lists, nil
 }
 
+func (c *ListController) GetList(user *user.AppUser, listID uuid.UUID) (*List, *controller.ControllerError) {
+	foundList, err := c.listRepo.GetList(listID, user)
+	if err != nil {
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("list with ID %v not found: %w", listID, err))
+	}
+	SortListItems(foundList.Items, c.preferencesOf(user).SortOrder)
+
+	return &foundList, nil
+}
+
 func (c *ListController) GetDefaultList(user *user.AppUser) (*DefaultList, *controller.ControllerError) {
 	defaultList, err := c.listRepo.GetDefaultList(user)
 	if err != nil {
@@ -48,9 +142,10 @@ func (c *ListController) GetDefaultList(user *user.AppUser) (*DefaultList, *cont
 
 func (c *ListController) CreateList(user *user.AppUser, addList *AddList) (*List, *controller.ControllerError) {
 	listToCreate := List{
-		ID:      uuid.New(),
-		Name:    addList.Name,
-		OwnerID: user.ID,
+		ID:          uuid.New(),
+		Name:        addList.Name,
+		OwnerID:     user.ID,
+		HouseholdID: user.HouseholdID,
 	}
 
 	listId, err := c.listRepo.CreateList(listToCreate)
@@ -81,6 +176,7 @@ func (c *ListController) UpdateList(user *user.AppUser, listID uuid.UUID, update
 	if err != nil {
 		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get updated list with ID %v: %w", listID, err))
 	}
+	c.publish(updatedList, EventListUpdated, updatedList)
 
 	return &updatedList, nil
 }
@@ -99,51 +195,252 @@ func (c *ListController) SetDefaultList(user *user.AppUser, listID uuid.UUID) (*
 	return &defaultList, nil
 }
 
+// SetBudget sets the budget of the list, or removes it if budget is nil
+func (c *ListController) SetBudget(user *user.AppUser, listID uuid.UUID, budget *Budget) (*List, *controller.ControllerError) {
+	if budget != nil {
+		if err := budget.Validate(); err != nil {
+			return nil, controller.CError(http.StatusBadRequest, err)
+		}
+	}
+
+	foundList, err := c.listRepo.GetList(listID, user)
+	if err != nil {
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("list with ID %v not found: %w", listID, err))
+	}
+
+	if err := c.listRepo.SetBudget(foundList, budget); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not set budget of list with ID %v: %w", listID, err))
+	}
+	c.checkBudget(user, listID)
+
+	updatedList, err := c.listRepo.GetList(listID, user)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get updated list with ID %v: %w", listID, err))
+	}
+	c.publish(updatedList, EventListUpdated, updatedList)
+
+	return &updatedList, nil
+}
+
+func (c *ListController) SetAutoClear(user *user.AppUser, listID uuid.UUID, autoClear *AutoClear) (*List, *controller.ControllerError) {
+	if autoClear != nil {
+		if err := autoClear.Validate(); err != nil {
+			return nil, controller.CError(http.StatusBadRequest, err)
+		}
+	}
+
+	foundList, err := c.listRepo.GetList(listID, user)
+	if err != nil {
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("list with ID %v not found: %w", listID, err))
+	}
+
+	if err := c.listRepo.SetAutoClear(foundList, autoClear); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not set auto-clear of list with ID %v: %w", listID, err))
+	}
+
+	updatedList, err := c.listRepo.GetList(listID, user)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get updated list with ID %v: %w", listID, err))
+	}
+	c.publish(updatedList, EventListUpdated, updatedList)
+
+	return &updatedList, nil
+}
+
 func (c *ListController) AddItemToList(user *user.AppUser, listID uuid.UUID, itemID uuid.UUID) (*ListItem, *controller.ControllerError) {
 	foundList, err := c.listRepo.GetList(listID, user)
 	if err != nil {
 		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("list with ID %v not found: %w", listID, err))
 	}
 
-	foundItem, err := c.itemRepo.GetItem(itemID)
+	foundItem, err := c.itemRepo.GetItem(itemID, foundList.HouseholdID)
 	if err != nil {
-		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("item with ID %v not found: %w", listID, err))
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("item with ID %v not found", itemID))
 	}
 
-	listItem, err := c.listRepo.AddItemToList(foundList, foundItem)
+	listItem, err := c.listRepo.AddItemToList(foundList, foundItem, 1, "")
 	if err != nil {
 		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not add item (%v) to list (%v): %w", itemID, listID, err))
 	}
+	c.publish(foundList, EventListItemsAdded, listItem)
+	c.checkBudget(user, listID)
 
 	return &listItem, nil
 }
 
+// AddMissingItemToList adds the item to the list, unless it is already on the list and not crossed.
+// Returns nil if the item was already on the list
+func (c *ListController) AddMissingItemToList(user *user.AppUser, listID uuid.UUID, itemID uuid.UUID, quantity float64) (*ListItem, *controller.ControllerError) {
+	foundList, err := c.listRepo.GetList(listID, user)
+	if err != nil {
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("list with ID %v not found: %w", listID, err))
+	}
+
+	for _, listItem := range foundList.Items {
+		if listItem.ItemID == itemID && !listItem.Crossed {
+			return nil, nil
+		}
+	}
+
+	foundItem, err := c.itemRepo.GetItem(itemID, foundList.HouseholdID)
+	if err != nil {
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("item with ID %v not found", itemID))
+	}
+
+	listItem, err := c.listRepo.AddItemToList(foundList, foundItem, quantity, "")
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not add item (%v) to list (%v): %w", itemID, listID, err))
+	}
+	c.publish(foundList, EventListItemsAdded, listItem)
+	c.checkBudget(user, listID)
+
+	return &listItem, nil
+}
+
+// MergeItemsIntoList adds the quantities to the uncrossed list items of the same item and a compatible unit,
+// or adds new list items if there are none. All list items are saved together, and the events are only published once they are
+func (c *ListController) MergeItemsIntoList(user *user.AppUser, listID uuid.UUID, itemQuantities []ItemQuantity) (*List, *controller.ControllerError) {
+	foundList, err := c.listRepo.GetList(listID, user)
+	if err != nil {
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("list with ID %v not found: %w", listID, err))
+	}
+
+	// New list items are appended to foundList.Items after the existing ones, so a later quantity of the same item
+	// is merged into them
+	updated := map[int]bool{}
+	existing := len(foundList.Items)
+	for _, itemQuantity := range itemQuantities {
+		itemUnit := unit.Normalize(itemQuantity.Unit)
+
+		merged := false
+		for i, listItem := range foundList.Items {
+			if listItem.ItemID != itemQuantity.ItemID || listItem.Crossed {
+				continue
+			}
+			quantity, ok := unit.Convert(itemQuantity.Quantity, itemUnit, listItem.Unit)
+			if !ok {
+				continue
+			}
+			foundList.Items[i].Quantity += quantity
+			updated[i] = true
+			merged = true
+			break
+		}
+		if merged {
+			continue
+		}
+
+		foundItem, err := c.itemRepo.GetItem(itemQuantity.ItemID, foundList.HouseholdID)
+		if err != nil {
+			return nil, controller.CError(http.StatusNotFound, fmt.Errorf("item with ID %v not found", itemQuantity.ItemID))
+		}
+		foundList.Items = append(foundList.Items, ListItem{
+			ID:       uuid.New(),
+			ListID:   foundList.ID,
+			ItemID:   foundItem.ID,
+			Item:     foundItem,
+			Quantity: itemQuantity.Quantity,
+			Unit:     itemUnit,
+		})
+	}
+
+	updatedItems := []ListItem{}
+	for i := 0; i < existing; i++ {
+		if updated[i] {
+			updatedItems = append(updatedItems, foundList.Items[i])
+		}
+	}
+	addedItems, err := c.listRepo.SaveListItems(updatedItems, foundList.Items[existing:])
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not merge items into list (%v): %w", listID, err))
+	}
+
+	for _, listItem := range updatedItems {
+		c.publish(foundList, EventListItemsUpdated, listItem)
+	}
+	for _, listItem := range addedItems {
+		c.publish(foundList, EventListItemsAdded, listItem)
+	}
+	c.checkBudget(user, listID)
+
+	updatedList, err := c.listRepo.GetList(listID, user)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get updated list with ID %v: %w", listID, err))
+	}
+
+	return &updatedList, nil
+}
+
 func (c *ListController) UpdateListItem(user *user.AppUser, listID uuid.UUID, listItemID uuid.UUID, updateListItem *UpdateListItem) (*ListItem, *controller.ControllerError) {
-	if _, err := c.listRepo.GetList(listID, user); err != nil {
+	foundList, err := c.listRepo.GetList(listID, user)
+	if err != nil {
 		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("list with ID %v not found: %w", listID, err))
 	}
 
 	listItem, err := c.listRepo.GetListItem(listItemID)
-	if err != nil {
-		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("listItem with ID %v not found: %w", listItemID, err))
+	if err != nil || listItem.ListID != foundList.ID {
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("listItem with ID %v not found", listItemID))
+	}
+
+	stockedQuantity := 0.0
+	if listItem.Stocked {
+		stockedQuantity = listItem.Quantity
 	}
 
 	listItem.Crossed = updateListItem.Crossed
+	if updateListItem.Quantity != nil {
+		if *updateListItem.Quantity <= 0 {
+			return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("quantity must be positive"))
+		}
+		listItem.Quantity = *updateListItem.Quantity
+	}
+
+	// Crossed items are in stock, so crossing, uncrossing or changing the quantity of a crossed item changes the stock
+	newStockedQuantity := 0.0
+	if listItem.Crossed {
+		newStockedQuantity = listItem.Quantity
+	}
+	if newStockedQuantity != stockedQuantity {
+		if err := c.stock.AddStock(foundList.HouseholdID, listItem.ItemID, newStockedQuantity-stockedQuantity, listItem.Unit); err != nil {
+			zap.S().Warnw("Could not update stock", "listItemID", listItem.ID, "error", err)
+		} else {
+			listItem.Stocked = listItem.Crossed
+		}
+	}
+
 	if err := c.listRepo.UpdateListItem(listItem); err != nil {
 		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not update ListItem with ID %v: %w", listItemID, err))
 	}
+	c.publish(foundList, EventListItemsUpdated, listItem)
+
+	if listItem.Crossed && c.preferencesOf(user).AutoClearCrossed && allCrossed(foundList.Items, listItem) {
+		// Clearing also checks the budget
+		if cErr := c.DeleteCrossedListItems(user, listID); cErr != nil {
+			zap.S().Warnw("Could not auto-clear crossed list items", "listID", listID, "error", cErr.Err)
+		}
+	} else {
+		c.checkBudget(user, listID)
+	}
 
 	return &listItem, nil
 }
 
 func (c *ListController) RemoveItemFromList(user *user.AppUser, listID uuid.UUID, listItemID uuid.UUID) *controller.ControllerError {
-	if _, err := c.listRepo.GetList(listID, user); err != nil {
+	foundList, err := c.listRepo.GetList(listID, user)
+	if err != nil {
 		return controller.CError(http.StatusNotFound, fmt.Errorf("list with ID %v not found: %w", listID, err))
 	}
 
-	if err := c.listRepo.RemoveItemFromList(listItemID); err != nil {
+	listItem, err := c.listRepo.GetListItem(listItemID)
+	if err != nil || listItem.ListID != foundList.ID {
+		return controller.CError(http.StatusNotFound, fmt.Errorf("listItem with ID %v not found", listItemID))
+	}
+
+	if err := c.listRepo.RemoveItemFromList(foundList, listItem.ID); err != nil {
 		return controller.CError(http.StatusInternalServerError, fmt.Errorf("could not remove listitem (%v) from list (%v): %w", listItemID, listID, err))
 	}
+	c.publish(foundList, EventListItemsRemoved, []uuid.UUID{listItemID})
+	c.checkBudget(user, listID)
 
 	return nil
 }
@@ -179,9 +476,64 @@ func (c *ListController) DeleteCrossedListItems(user *user.AppUser, listID uuid.
 		return controller.CError(http.StatusNotFound, fmt.Errorf("list with ID %v not found: %w", listID, err))
 	}
 
-	if err := c.listRepo.DeleteCrossedListItems(foundList); err != nil {
+	deletedItems, err := c.listRepo.DeleteCrossedListItems(foundList, user)
+	if err != nil {
 		return controller.CError(http.StatusInternalServerError, fmt.Errorf("could not delete crossed list items (%v): %w", listID, err))
 	}
+	// Only the items that were deleted are stocked, so a failed clear that is retried does not stock them twice
+	c.stockDeleted(foundList, deletedItems)
+	c.publish(foundList, EventListItemsRemoved, listItemIDs(deletedItems))
+	c.checkBudget(user, listID)
 
 	return nil
 }
+
+// stockDeleted adds the deleted list items that are not in stock yet to the stock
+func (c *ListController) stockDeleted(list List, deletedItems []ListItem) {
+	for _, listItem := range deletedItems {
+		if listItem.Stocked {
+			continue
+		}
+		if err := c.stock.AddStock(list.HouseholdID, listItem.ItemID, listItem.Quantity, listItem.Unit); err != nil {
+			zap.S().Warnw("Could not update stock", "listItemID", listItem.ID, "error", err)
+		}
+	}
+}
+
+func listItemIDs(listItems []ListItem) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(listItems))
+	for _, listItem := range listItems {
+		ids = append(ids, listItem.ID)
+	}
+	return ids
+}
+
+// AutoClearCrossedListItems clears the items of the list that were crossed longer ago than its auto-clear setting,
+// the same way DeleteCrossedListItems does. The purchases are recorded for the owner of the list
+func (c *ListController) AutoClearCrossedListItems(list List, now time.Time) ([]uuid.UUID, error) {
+	if list.AutoClearAfterHours == nil {
+		return []uuid.UUID{}, nil
+	}
+	owner := &user.AppUser{ID: list.OwnerID, HouseholdID: list.HouseholdID}
+	foundList, err := c.listRepo.GetList(list.ID, owner)
+	if err != nil {
+		return nil, err
+	}
+	crossedBefore := now.Add(-time.Duration(*foundList.AutoClearAfterHours) * time.Hour)
+
+	// The items are stocked from the rows that were deleted, not from the list read above,
+	// since they may have been uncrossed or cleared by someone else in the meantime
+	clearedItems, err := c.listRepo.DeleteCrossedListItemsBefore(foundList, owner.ID, crossedBefore)
+	if err != nil {
+		return nil, err
+	}
+	c.stockDeleted(foundList, clearedItems)
+
+	clearedIDs := listItemIDs(clearedItems)
+	if len(clearedIDs) > 0 {
+		c.publish(foundList, EventListItemsRemoved, clearedIDs)
+		c.checkBudget(owner, foundList.ID)
+	}
+
+	return clearedIDs, nil
+}
@@ -2,20 +2,37 @@ package list
 
 import (
 	"ShoppingList-Backend/internal/pkg/item"
+	"ShoppingList-Backend/internal/pkg/user"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var currencyRegexp = regexp.MustCompile(`^[A-Z]{3}$`)
+
 type List struct {
 	ID        uuid.UUID  `db:"id" json:"id" validate:"required,uuid"`
 	CreatedAt time.Time  `db:"created_at" json:"createdAt"`
 	UpdatedAt *time.Time `db:"updated_at" json:"updatedAt"`
 	DeletedAt *time.Time `db:"deleted_at" json:"deletedAt"`
-	OwnerID   string     `db:"owner_id" json:"ownerId"`
+	// OwnerID is the user that created the list
+	OwnerID     string    `db:"owner_id" json:"ownerId"`
+	HouseholdID uuid.UUID `db:"household_id" json:"householdId"`
 
 	Name  string     `db:"name" json:"name"`
 	Items []ListItem `db:"list_item" json:"items"`
+
+	// Budget in minor units of BudgetCurrency
+	BudgetAmount   *int64  `db:"budget_amount" json:"budgetAmount"`
+	BudgetCurrency *string `db:"budget_currency" json:"budgetCurrency"`
+	BudgetExceeded bool    `db:"budget_exceeded" json:"budgetExceeded"`
+
+	// Crossed items are cleared this many hours after they were crossed, if set
+	AutoClearAfterHours *int `db:"auto_clear_after_hours" json:"autoClearAfterHours"`
 }
 type AddList struct {
 	Name string `json:"name"`
@@ -29,15 +46,98 @@ type ListItem struct {
 	ItemID    uuid.UUID  `db:"item_id" json:"itemId"`
 	Item      item.Item  `db:"item" json:"item"`
 	Crossed   bool       `db:"crossed" json:"crossed"`
+	Quantity  float64    `db:"quantity" json:"quantity"`
+	Unit      string     `db:"unit" json:"unit"`
+	Stocked   bool       `db:"stocked" json:"-"`
 }
 type UpdateListItem struct {
-	Crossed bool `json:"crossed"`
+	Crossed  bool     `json:"crossed"`
+	Quantity *float64 `json:"quantity"`
+}
+
+type Budget struct {
+	// Amount in minor units of the currency, e.g. 50000 for 500.00
+	Amount int64 `json:"amount"`
+	// ISO 4217 currency code, e.g. DKK
+	Currency string `json:"currency"`
+}
+
+func (b *Budget) Validate() error {
+	b.Currency = strings.ToUpper(strings.TrimSpace(b.Currency))
+	if b.Amount < 0 {
+		return fmt.Errorf("amount must not be negative")
+	}
+	if !currencyRegexp.MatchString(b.Currency) {
+		return fmt.Errorf("currency must be a 3 letter ISO 4217 code, got %v", b.Currency)
+	}
+	return nil
+}
+
+type AutoClear struct {
+	// Hours after an item is crossed until it is cleared
+	AfterHours int `json:"afterHours"`
+}
+
+func (a *AutoClear) Validate() error {
+	if a.AfterHours <= 0 {
+		return fmt.Errorf("afterHours must be positive")
+	}
+	return nil
+}
+
+type BudgetExceeded struct {
+	ListID    uuid.UUID `json:"listId"`
+	Budget    int64     `json:"budget"`
+	Estimated int64     `json:"estimated"`
+	Currency  string    `json:"currency"`
+}
+
+// ItemQuantity is an amount of an item to put on a list
+type ItemQuantity struct {
+	ItemID   uuid.UUID
+	Quantity float64
+	Unit     string
 }
 
 type DefaultList struct {
-	ID        uuid.UUID  `db:"id" json:"id"`
-	CreatedAt time.Time  `db:"created_at" json:"createdAt"`
-	UpdatedAt *time.Time `db:"updated_at" json:"updatedAt"`
-	UserID    string     `db:"app_user_id" json:"userId"`
-	ListID    uuid.UUID  `db:"list_id" json:"listId"`
+	ID          uuid.UUID  `db:"id" json:"id"`
+	CreatedAt   time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt   *time.Time `db:"updated_at" json:"updatedAt"`
+	UserID      string     `db:"app_user_id" json:"userId"`
+	HouseholdID uuid.UUID  `db:"household_id" json:"householdId"`
+	ListID      uuid.UUID  `db:"list_id" json:"listId"`
+}
+
+// allCrossed tells if all items on the list are crossed, with updatedListItem replacing its old version
+func allCrossed(listItems []ListItem, updatedListItem ListItem) bool {
+	for _, listItem := range listItems {
+		if listItem.ID == updatedListItem.ID {
+			listItem = updatedListItem
+		}
+		if !listItem.Crossed {
+			return false
+		}
+	}
+	return true
+}
+
+func (li ListItem) lastChanged() time.Time {
+	if li.UpdatedAt != nil {
+		return *li.UpdatedAt
+	}
+	return li.CreatedAt
+}
+
+// SortListItems sorts the list items in the sort order of the user preferences
+func SortListItems(listItems []ListItem, sortOrder string) {
+	sort.SliceStable(listItems, func(i, j int) bool {
+		switch sortOrder {
+		case user.SortOrderName:
+			return strings.ToLower(listItems[i].Item.Name) < strings.ToLower(listItems[j].Item.Name)
+		case user.SortOrderAdded:
+			return listItems[i].CreatedAt.Before(listItems[j].CreatedAt)
+		default:
+			return listItems[i].lastChanged().After(listItems[j].lastChanged())
+		}
+	})
 }
@@ -5,6 +5,7 @@ import (
 	"ShoppingList-Backend/internal/pkg/user"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -95,9 +96,9 @@ func (q *ListRepository) populateWithItems(lists []List) error {
 func (q *ListRepository) GetLists(owner *user.AppUser) ([]List, error) {
 	lists := []List{}
 
-	query := `SELECT * FROM lists WHERE owner_id = $1 AND deleted_at IS NULL ORDER BY created_at ASC`
+	query := `SELECT * FROM lists WHERE household_id = $1 AND deleted_at IS NULL ORDER BY created_at ASC`
 
-	err := q.DB.Select(&lists, query, owner.ID)
+	err := q.DB.Select(&lists, query, owner.HouseholdID)
 
 	if err != nil {
 		return lists, err
@@ -133,8 +134,7 @@ func (q *ListRepository) GetList(id uuid.UUID, appUser *user.AppUser) (List, err
 		return list, err
 	}
 
-	// TODO: check if list is shared with user
-	if list.OwnerID != appUser.ID /* || list is shared with user */ {
+	if list.HouseholdID != appUser.HouseholdID {
 		return list, errors.New("access not allowed")
 	}
 
@@ -149,8 +149,8 @@ func (q *ListRepository) GetList(id uuid.UUID, appUser *user.AppUser) (List, err
 }
 
 func (q *ListRepository) CreateList(list List) (uuid.UUID, error) {
-	query := `INSERT INTO lists (id, name, owner_id) VALUES ($1, $2, $3)`
-	_, err := q.DB.Exec(query, list.ID, list.Name, list.OwnerID)
+	query := `INSERT INTO lists (id, name, owner_id, household_id) VALUES ($1, $2, $3, $4)`
+	_, err := q.DB.Exec(query, list.ID, list.Name, list.OwnerID, list.HouseholdID)
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -167,10 +167,61 @@ func (q *ListRepository) UpdateList(list List) error {
 	return nil
 }
 
-func (q *ListRepository) AddItemToList(list List, item item.Item) (ListItem, error) {
+// SetBudget sets the budget of the list, or removes it if budget is nil
+func (q *ListRepository) SetBudget(list List, budget *Budget) error {
+	var amount *int64
+	var currency *string
+	if budget != nil {
+		amount = &budget.Amount
+		currency = &budget.Currency
+	}
+	query := `UPDATE lists SET updated_at = NOW(), budget_amount = $2, budget_currency = $3, budget_exceeded = FALSE WHERE id = $1`
+	_, err := q.DB.Exec(query, list.ID, amount, currency)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (q *ListRepository) SetBudgetExceeded(list List, exceeded bool) error {
+	query := `UPDATE lists SET budget_exceeded = $2 WHERE id = $1`
+	_, err := q.DB.Exec(query, list.ID, exceeded)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (q *ListRepository) SetAutoClear(list List, autoClear *AutoClear) error {
+	var afterHours *int
+	if autoClear != nil {
+		afterHours = &autoClear.AfterHours
+	}
+	query := `UPDATE lists SET updated_at = NOW(), auto_clear_after_hours = $2 WHERE id = $1`
+	_, err := q.DB.Exec(query, list.ID, afterHours)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetListsToAutoClear returns the lists with auto-clearing that have items which were crossed long enough ago to be cleared
+func (q *ListRepository) GetListsToAutoClear(now time.Time) ([]List, error) {
+	lists := []List{}
+	query := `SELECT * FROM lists WHERE deleted_at IS NULL AND auto_clear_after_hours IS NOT NULL AND EXISTS (
+		SELECT 1 FROM list_item WHERE list_item.list_id = lists.id AND list_item.crossed = true
+		AND COALESCE(list_item.updated_at, list_item.created_at) <= $1::timestamptz - make_interval(hours => lists.auto_clear_after_hours))`
+	err := q.DB.Select(&lists, query, now)
+	if err != nil {
+		return lists, err
+	}
+	return lists, nil
+}
+
+func (q *ListRepository) AddItemToList(list List, item item.Item, quantity float64, unit string) (ListItem, error) {
 	listItem := ListItem{ID: uuid.New()}
-	query := `INSERT INTO list_item (id, list_id, item_id) VALUES ($1, $2, $3)`
-	_, err := q.DB.Exec(query, listItem.ID, list.ID, item.ID)
+	query := `INSERT INTO list_item (id, list_id, item_id, quantity, unit) VALUES ($1, $2, $3, $4, $5)`
+	_, err := q.DB.Exec(query, listItem.ID, list.ID, item.ID, quantity, unit)
 	if err != nil {
 		return listItem, err
 	}
@@ -184,14 +235,47 @@ func (q *ListRepository) AddItemToList(list List, item item.Item) (ListItem, err
 }
 
 func (q *ListRepository) UpdateListItem(listItem ListItem) error {
-	query := `UPDATE list_item SET updated_at = NOW(), crossed = $1 WHERE id = $2`
-	_, err := q.DB.Exec(query, listItem.Crossed, listItem.ID)
+	query := `UPDATE list_item SET updated_at = NOW(), crossed = $1, quantity = $2, unit = $3, stocked = $4 WHERE id = $5`
+	_, err := q.DB.Exec(query, listItem.Crossed, listItem.Quantity, listItem.Unit, listItem.Stocked, listItem.ID)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// SaveListItems updates the list items and adds the new ones in one transaction, so either all of them are saved or none.
+// The new list items are returned as they were added
+func (q *ListRepository) SaveListItems(updatedItems []ListItem, newItems []ListItem) ([]ListItem, error) {
+	tx, err := q.DB.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	for _, listItem := range updatedItems {
+		query := `UPDATE list_item SET updated_at = NOW(), crossed = $1, quantity = $2, unit = $3, stocked = $4 WHERE id = $5`
+		if _, err := tx.Exec(query, listItem.Crossed, listItem.Quantity, listItem.Unit, listItem.Stocked, listItem.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	addedItems := []ListItem{}
+	for _, listItem := range newItems {
+		addedItem := ListItem{}
+		query := `INSERT INTO list_item (id, list_id, item_id, quantity, unit) VALUES ($1, $2, $3, $4, $5) RETURNING *`
+		if err := tx.Get(&addedItem, query, listItem.ID, listItem.ListID, listItem.ItemID, listItem.Quantity, listItem.Unit); err != nil {
+			return nil, err
+		}
+		addedItem.Item = listItem.Item
+		addedItems = append(addedItems, addedItem)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return addedItems, nil
+}
+
 func (q *ListRepository) GetListItem(id uuid.UUID) (ListItem, error) {
 	listItem := ListItem{}
 	query := `SELECT * FROM list_item where id = $1`
@@ -209,9 +293,9 @@ func (q *ListRepository) GetListItem(id uuid.UUID) (ListItem, error) {
 	return listItem, nil
 }
 
-func (q *ListRepository) RemoveItemFromList(id uuid.UUID) error {
-	query := `DELETE FROM list_item WHERE id = $1`
-	_, err := q.DB.Exec(query, id)
+func (q *ListRepository) RemoveItemFromList(list List, id uuid.UUID) error {
+	query := `DELETE FROM list_item WHERE id = $1 AND list_id = $2`
+	_, err := q.DB.Exec(query, id, list.ID)
 	if err != nil {
 		return err
 	}
@@ -227,19 +311,42 @@ func (q *ListRepository) DeleteList(list List) error {
 	return nil
 }
 
-func (q *ListRepository) DeleteCrossedListItems(list List) error {
-	query := `DELETE FROM list_item WHERE list_id = $1 AND crossed = true`
-	_, err := q.DB.Exec(query, list.ID)
-	if err != nil {
-		return err
+// DeleteCrossedListItems records the crossed items as purchased by the user and deletes them. The deleted list items are returned
+func (q *ListRepository) DeleteCrossedListItems(list List, user *user.AppUser) ([]ListItem, error) {
+	return q.deleteCrossedListItems(list, user.ID, nil)
+}
+
+// DeleteCrossedListItemsBefore deletes the items that were crossed before crossedBefore, like DeleteCrossedListItems.
+// The purchases are recorded for the user, and the deleted list items are returned
+func (q *ListRepository) DeleteCrossedListItemsBefore(list List, userID string, crossedBefore time.Time) ([]ListItem, error) {
+	return q.deleteCrossedListItems(list, userID, &crossedBefore)
+}
+
+// deleteCrossedListItems records the crossed items as purchased and deletes them. If crossedBefore is set, only the items crossed before it are deleted.
+// The purchases are recorded from the deleted rows in the same statement, so items changed concurrently are either both purchased and deleted, or neither
+func (q *ListRepository) deleteCrossedListItems(list List, userID string, crossedBefore *time.Time) ([]ListItem, error) {
+	// An item is considered bought when it was crossed, which is the last time it was updated
+	query := `WITH deleted AS (
+			DELETE FROM list_item WHERE list_id = $1 AND crossed = true
+			AND ($4::timestamptz IS NULL OR COALESCE(updated_at, created_at) <= $4)
+			RETURNING id, created_at, updated_at, list_id, item_id, crossed, quantity, unit, stocked
+		), purchased AS (
+			INSERT INTO purchases (purchased_at, item_id, list_id, user_id, household_id, quantity)
+			SELECT COALESCE(updated_at, created_at), item_id, list_id, $2, $3, quantity FROM deleted
+		)
+		SELECT * FROM deleted`
+	deletedItems := []ListItem{}
+	if err := q.DB.Select(&deletedItems, query, list.ID, userID, list.HouseholdID, crossedBefore); err != nil {
+		return nil, err
 	}
-	return nil
+	return deletedItems, nil
 }
 
+// GetDefaultList returns the default list of the user in the household of the request. Users have a default list per household
 func (q *ListRepository) GetDefaultList(user *user.AppUser) (DefaultList, error) {
-	fetchQuery := `SELECT * FROM default_lists WHERE app_user_id = $1 LIMIT 1`
+	fetchQuery := `SELECT * FROM default_lists WHERE app_user_id = $1 AND household_id = $2 LIMIT 1`
 	defaultList := DefaultList{}
-	err := q.DB.Get(&defaultList, fetchQuery, user.ID)
+	err := q.DB.Get(&defaultList, fetchQuery, user.ID, user.HouseholdID)
 	if err != nil {
 		return defaultList, err
 	}
@@ -247,20 +354,20 @@ func (q *ListRepository) GetDefaultList(user *user.AppUser) (DefaultList, error)
 }
 
 func (q *ListRepository) ClearDefaultList(user *user.AppUser) error {
-	query := `DELETE FROM default_lists WHERE app_user_id = $1`
-	_, err := q.DB.Exec(query, user.ID)
+	query := `DELETE FROM default_lists WHERE app_user_id = $1 AND household_id = $2`
+	_, err := q.DB.Exec(query, user.ID, user.HouseholdID)
 	return err
 }
 
 func (q *ListRepository) SetDefaultList(user *user.AppUser, list List) (DefaultList, error) {
-	fetchQuery := `SELECT * FROM default_lists WHERE app_user_id = $1 LIMIT 1`
+	fetchQuery := `SELECT * FROM default_lists WHERE app_user_id = $1 AND household_id = $2 LIMIT 1`
 	currentDefaultList := DefaultList{}
-	err := q.DB.Get(&currentDefaultList, fetchQuery, user.ID)
+	err := q.DB.Get(&currentDefaultList, fetchQuery, user.ID, list.HouseholdID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			// User does not have a default list, create one
-			insertQuery := `INSERT INTO default_lists (app_user_id, list_id) VALUES ($1, $2)`
-			_, err := q.DB.Exec(insertQuery, user.ID, list.ID)
+			insertQuery := `INSERT INTO default_lists (app_user_id, household_id, list_id) VALUES ($1, $2, $3)`
+			_, err := q.DB.Exec(insertQuery, user.ID, list.HouseholdID, list.ID)
 			if err != nil {
 				return currentDefaultList, err
 			}
@@ -269,8 +376,8 @@ func (q *ListRepository) SetDefaultList(user *user.AppUser, list List) (DefaultL
 		}
 	} else if currentDefaultList.ListID != list.ID {
 		// User already has a default list, so update it
-		updateQuery := `UPDATE default_lists SET list_id = $2, updated_at = NOW() WHERE app_user_id = $1`
-		_, err := q.DB.Exec(updateQuery, user.ID, list.ID)
+		updateQuery := `UPDATE default_lists SET list_id = $3, updated_at = NOW() WHERE app_user_id = $1 AND household_id = $2`
+		_, err := q.DB.Exec(updateQuery, user.ID, list.HouseholdID, list.ID)
 		if err != nil {
 			return currentDefaultList, err
 		}
@@ -278,7 +385,7 @@ func (q *ListRepository) SetDefaultList(user *user.AppUser, list List) (DefaultL
 
 	// If the default list was updated or created, fetch again
 	if currentDefaultList.ListID != list.ID {
-		err = q.DB.Get(&currentDefaultList, fetchQuery, user.ID)
+		err = q.DB.Get(&currentDefaultList, fetchQuery, user.ID, list.HouseholdID)
 		if err != nil {
 			return currentDefaultList, err
 		}
@@ -0,0 +1,50 @@
+package mealplan
+
+import (
+	"ShoppingList-Backend/internal/pkg/list"
+	"ShoppingList-Backend/internal/pkg/unit"
+
+	"github.com/google/uuid"
+)
+
+type itemUnit struct {
+	itemID uuid.UUID
+	unit   string
+}
+
+// Consolidate sums up the needed quantities per item and unit, converting units where possible,
+// and subtracts what is already available. Items that are fully available are left out.
+func Consolidate(needed []list.ItemQuantity, available []list.ItemQuantity) []list.ItemQuantity {
+	order := []itemUnit{}
+	totals := make(map[itemUnit]float64)
+	for _, itemQuantity := range needed {
+		quantity, baseUnit := unit.ToBase(itemQuantity.Quantity, itemQuantity.Unit)
+		key := itemUnit{itemQuantity.ItemID, baseUnit}
+		if _, ok := totals[key]; !ok {
+			order = append(order, key)
+		}
+		totals[key] += quantity
+	}
+
+	for _, itemQuantity := range available {
+		quantity, baseUnit := unit.ToBase(itemQuantity.Quantity, itemQuantity.Unit)
+		key := itemUnit{itemQuantity.ItemID, baseUnit}
+		if _, ok := totals[key]; ok {
+			totals[key] -= quantity
+		}
+	}
+
+	consolidated := []list.ItemQuantity{}
+	for _, key := range order {
+		if totals[key] <= 0 {
+			continue
+		}
+		quantity, humanUnit := unit.Humanize(totals[key], key.unit)
+		consolidated = append(consolidated, list.ItemQuantity{
+			ItemID:   key.itemID,
+			Quantity: quantity,
+			Unit:     humanUnit,
+		})
+	}
+	return consolidated
+}
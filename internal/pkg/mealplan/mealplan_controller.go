@@ -0,0 +1,182 @@
+package mealplan
+
+import (
+	"ShoppingList-Backend/internal/pkg/controller"
+	"ShoppingList-Backend/internal/pkg/list"
+	"ShoppingList-Backend/internal/pkg/pantry"
+	"ShoppingList-Backend/internal/pkg/recipe"
+	"ShoppingList-Backend/internal/pkg/user"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MealPlanController struct {
+	mealPlanRepo   *MealPlanRepository
+	recipeRepo     *recipe.RecipeRepository
+	pantryRepo     *pantry.PantryRepository
+	listController *list.ListController
+}
+
+func NewMealPlanController(mealPlanRepo *MealPlanRepository, recipeRepo *recipe.RecipeRepository, pantryRepo *pantry.PantryRepository, listController *list.ListController) *MealPlanController {
+	return &MealPlanController{
+		mealPlanRepo:   mealPlanRepo,
+		recipeRepo:     recipeRepo,
+		pantryRepo:     pantryRepo,
+		listController: listController,
+	}
+}
+
+func parseDateRange(fromStr string, toStr string) (time.Time, time.Time, *controller.ControllerError) {
+	from, err := time.Parse(DateFormat, fromStr)
+	if err != nil {
+		return from, from, controller.CError(http.StatusBadRequest, fmt.Errorf("could not parse from %v: %w", fromStr, err))
+	}
+	to, err := time.Parse(DateFormat, toStr)
+	if err != nil {
+		return from, to, controller.CError(http.StatusBadRequest, fmt.Errorf("could not parse to %v: %w", toStr, err))
+	}
+	if to.Before(from) {
+		return from, to, controller.CError(http.StatusBadRequest, fmt.Errorf("from (%v) must not be after to (%v)", fromStr, toStr))
+	}
+	return from, to, nil
+}
+
+// getEntries returns the entries in the date range, with their recipes
+func (c *MealPlanController) getEntries(user *user.AppUser, from time.Time, to time.Time) ([]Entry, error) {
+	entries, err := c.mealPlanRepo.GetEntries(user.HouseholdID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	recipesById := make(map[uuid.UUID]*recipe.Recipe)
+	for i, entry := range entries {
+		if _, ok := recipesById[entry.RecipeID]; !ok {
+			foundRecipe, err := c.recipeRepo.GetRecipe(entry.RecipeID)
+			if err != nil {
+				return nil, fmt.Errorf("could not get recipe with ID %v: %w", entry.RecipeID, err)
+			}
+			recipesById[entry.RecipeID] = &foundRecipe
+		}
+		entries[i].Recipe = recipesById[entry.RecipeID]
+	}
+
+	return entries, nil
+}
+
+func (c *MealPlanController) GetEntries(user *user.AppUser, fromStr string, toStr string) ([]Entry, *controller.ControllerError) {
+	from, to, cErr := parseDateRange(fromStr, toStr)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	entries, err := c.getEntries(user, from, to)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get meal plan: %w", err))
+	}
+	return entries, nil
+}
+
+func (c *MealPlanController) CreateEntry(user *user.AppUser, addEntry *AddEntry) (*Entry, *controller.ControllerError) {
+	date, err := time.Parse(DateFormat, addEntry.Date)
+	if err != nil {
+		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("could not parse date %v: %w", addEntry.Date, err))
+	}
+	if addEntry.Servings != nil && *addEntry.Servings <= 0 {
+		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("servings must be positive"))
+	}
+
+	foundRecipe, err := c.recipeRepo.GetRecipe(addEntry.RecipeID)
+	if err != nil || foundRecipe.HouseholdID != user.HouseholdID {
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("recipe with ID %v not found", addEntry.RecipeID))
+	}
+
+	entryToCreate := Entry{
+		ID:          uuid.New(),
+		OwnerID:     user.ID,
+		HouseholdID: user.HouseholdID,
+		Date:        date,
+		RecipeID:    addEntry.RecipeID,
+		Servings:    addEntry.Servings,
+	}
+	entryID, err := c.mealPlanRepo.CreateEntry(entryToCreate)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not create meal plan entry: %w", err))
+	}
+
+	createdEntry, err := c.mealPlanRepo.GetEntry(entryID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get created meal plan entry with ID %v: %w", entryID, err))
+	}
+	createdEntry.Recipe = &foundRecipe
+
+	return &createdEntry, nil
+}
+
+func (c *MealPlanController) DeleteEntry(user *user.AppUser, entryID uuid.UUID) *controller.ControllerError {
+	foundEntry, err := c.mealPlanRepo.GetEntry(entryID)
+	if err != nil || foundEntry.HouseholdID != user.HouseholdID {
+		return controller.CError(http.StatusNotFound, fmt.Errorf("meal plan entry with ID %v not found", entryID))
+	}
+
+	if err := c.mealPlanRepo.DeleteEntry(foundEntry); err != nil {
+		return controller.CError(http.StatusInternalServerError, fmt.Errorf("could not delete meal plan entry with ID %v: %w", entryID, err))
+	}
+
+	return nil
+}
+
+// GenerateShoppingList adds the ingredients of all recipes planned in the date range to a list.
+// Ingredients already on the list or in the pantry are subtracted.
+func (c *MealPlanController) GenerateShoppingList(user *user.AppUser, generate *GenerateShoppingList) (*list.List, *controller.ControllerError) {
+	from, to, cErr := parseDateRange(generate.From, generate.To)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	entries, err := c.getEntries(user, from, to)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get meal plan: %w", err))
+	}
+
+	var targetList *list.List
+	if generate.ListID != nil {
+		targetList, cErr = c.listController.GetList(user, *generate.ListID)
+	} else {
+		name := generate.Name
+		if name == "" {
+			name = fmt.Sprintf("Meal plan %v - %v", generate.From, generate.To)
+		}
+		targetList, cErr = c.listController.CreateList(user, &list.AddList{Name: name})
+	}
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	needed := []list.ItemQuantity{}
+	for _, entry := range entries {
+		servings := entry.Recipe.Servings
+		if entry.Servings != nil {
+			servings = *entry.Servings
+		}
+		needed = append(needed, recipe.ScaledIngredients(*entry.Recipe, servings)...)
+	}
+
+	available := []list.ItemQuantity{}
+	for _, listItem := range targetList.Items {
+		if !listItem.Crossed {
+			available = append(available, list.ItemQuantity{ItemID: listItem.ItemID, Quantity: listItem.Quantity, Unit: listItem.Unit})
+		}
+	}
+	pantryItems, err := c.pantryRepo.GetPantryItems(user.HouseholdID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get pantry: %w", err))
+	}
+	for _, pantryItem := range pantryItems {
+		available = append(available, list.ItemQuantity{ItemID: pantryItem.ItemID, Quantity: pantryItem.Quantity, Unit: pantryItem.Unit})
+	}
+
+	return c.listController.MergeItemsIntoList(user, targetList.ID, Consolidate(needed, available))
+}
@@ -0,0 +1,43 @@
+package mealplan
+
+import (
+	"ShoppingList-Backend/internal/pkg/recipe"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const DateFormat = "2006-01-02"
+
+type Entry struct {
+	ID        uuid.UUID  `db:"id" json:"id"`
+	CreatedAt time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt *time.Time `db:"updated_at" json:"updatedAt"`
+	// OwnerID is the user that created the entry
+	OwnerID     string    `db:"owner_id" json:"ownerId"`
+	HouseholdID uuid.UUID `db:"household_id" json:"householdId"`
+
+	Date     time.Time      `db:"date" json:"date"`
+	RecipeID uuid.UUID      `db:"recipe_id" json:"recipeId"`
+	Recipe   *recipe.Recipe `db:"-" json:"recipe"`
+	// Servings defaults to the servings of the recipe
+	Servings *int `db:"servings" json:"servings"`
+}
+
+type AddEntry struct {
+	// Date in YYYY-MM-DD format
+	Date     string    `json:"date"`
+	RecipeID uuid.UUID `json:"recipeId"`
+	Servings *int      `json:"servings"`
+}
+
+type GenerateShoppingList struct {
+	// From date (inclusive) in YYYY-MM-DD format
+	From string `json:"from"`
+	// To date (inclusive) in YYYY-MM-DD format
+	To string `json:"to"`
+	// ListID of the list to add the items to. If not set, a new list is created
+	ListID *uuid.UUID `json:"listId"`
+	// Name of the new list, if ListID is not set
+	Name string `json:"name"`
+}
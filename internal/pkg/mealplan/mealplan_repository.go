@@ -0,0 +1,50 @@
+package mealplan
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type MealPlanRepository struct {
+	DB *sqlx.DB
+}
+
+// GetEntries returns the entries of the household between from and to, both inclusive. Entries of deleted recipes are left out
+func (q *MealPlanRepository) GetEntries(householdID uuid.UUID, from time.Time, to time.Time) ([]Entry, error) {
+	entries := []Entry{}
+	query := `SELECT meal_plan_entries.* FROM meal_plan_entries JOIN recipes ON recipes.id = meal_plan_entries.recipe_id AND recipes.deleted_at IS NULL
+		WHERE meal_plan_entries.household_id = $1 AND meal_plan_entries.date >= $2 AND meal_plan_entries.date <= $3
+		ORDER BY meal_plan_entries.date ASC, meal_plan_entries.created_at ASC`
+	err := q.DB.Select(&entries, query, householdID, from, to)
+	if err != nil {
+		return entries, err
+	}
+	return entries, nil
+}
+
+func (q *MealPlanRepository) GetEntry(id uuid.UUID) (Entry, error) {
+	entry := Entry{}
+	query := `SELECT * FROM meal_plan_entries WHERE id = $1`
+	err := q.DB.Get(&entry, query, id)
+	return entry, err
+}
+
+func (q *MealPlanRepository) CreateEntry(entry Entry) (uuid.UUID, error) {
+	query := `INSERT INTO meal_plan_entries (id, owner_id, household_id, date, recipe_id, servings) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := q.DB.Exec(query, entry.ID, entry.OwnerID, entry.HouseholdID, entry.Date, entry.RecipeID, entry.Servings)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return entry.ID, nil
+}
+
+func (q *MealPlanRepository) DeleteEntry(entry Entry) error {
+	query := `DELETE FROM meal_plan_entries WHERE id = $1`
+	_, err := q.DB.Exec(query, entry.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -7,9 +7,11 @@ import (
 	"go.uber.org/zap"
 )
 
+const migrationsDir = "db/migrations"
+
 func DoMigration(direction string, dbConnStr string) {
 	log := zap.S()
-	m, err := migrate.New("file://db/migrations", dbConnStr)
+	m, err := migrate.New("file://"+migrationsDir, dbConnStr)
 	if err != nil {
 		log.Fatalf("Error getting migration files: %v", err)
 	}
@@ -0,0 +1,50 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/jmoiron/sqlx"
+)
+
+// LatestVersion is the version of the newest migration in the migrations folder
+func LatestVersion() (uint, error) {
+	entries, err := os.ReadDir(migrationsDir)
+	if err != nil {
+		return 0, fmt.Errorf("could not read migration files: %w", err)
+	}
+	var latest uint
+	for _, entry := range entries {
+		m, err := source.Parse(entry.Name())
+		if err != nil {
+			continue
+		}
+		if m.Version > latest {
+			latest = m.Version
+		}
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("no migration files in %v", migrationsDir)
+	}
+	return latest, nil
+}
+
+// DatabaseVersion is the version the database has been migrated to, and whether the migration failed
+func DatabaseVersion(ctx context.Context, db *sqlx.DB) (uint, bool, error) {
+	var status struct {
+		Version int64 `db:"version"`
+		Dirty   bool  `db:"dirty"`
+	}
+	err := db.GetContext(ctx, &status, "SELECT version, dirty FROM schema_migrations LIMIT 1")
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("could not get migration version: %w", err)
+	}
+	return uint(status.Version), status.Dirty, nil
+}
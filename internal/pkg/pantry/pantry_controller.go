@@ -0,0 +1,143 @@
+package pantry
+
+import (
+	"ShoppingList-Backend/internal/pkg/controller"
+	"ShoppingList-Backend/internal/pkg/item"
+	"ShoppingList-Backend/internal/pkg/list"
+	"ShoppingList-Backend/internal/pkg/unit"
+	"ShoppingList-Backend/internal/pkg/user"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type PantryController struct {
+	pantryRepo     *PantryRepository
+	itemRepo       *item.ItemRepository
+	listController *list.ListController
+}
+
+func NewPantryController(pantryRepo *PantryRepository, itemRepo *item.ItemRepository, listController *list.ListController) *PantryController {
+	return &PantryController{
+		pantryRepo:     pantryRepo,
+		itemRepo:       itemRepo,
+		listController: listController,
+	}
+}
+
+func (c *PantryController) GetPantryItems(user *user.AppUser) ([]PantryItem, *controller.ControllerError) {
+	pantryItems, err := c.pantryRepo.GetPantryItems(user.HouseholdID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get pantry: %w", err))
+	}
+	return pantryItems, nil
+}
+
+func (c *PantryController) UpdatePantryItem(user *user.AppUser, itemID uuid.UUID, updatePantryItem *UpdatePantryItem) (*PantryItem, *controller.ControllerError) {
+	if updatePantryItem.Quantity < 0 {
+		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("quantity must not be negative"))
+	}
+	if updatePantryItem.MinQuantity != nil && *updatePantryItem.MinQuantity < 0 {
+		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("minQuantity must not be negative"))
+	}
+
+	if _, err := c.itemRepo.GetItem(itemID, user.HouseholdID); err != nil {
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("item with ID %v not found", itemID))
+	}
+
+	pantryItem, err := c.pantryRepo.GetPantryItem(user.HouseholdID, itemID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get pantry item for item with ID %v: %w", itemID, err))
+		}
+		pantryItem = PantryItem{
+			ID:          uuid.New(),
+			HouseholdID: user.HouseholdID,
+			ItemID:      itemID,
+		}
+	}
+
+	pantryItem.Quantity = updatePantryItem.Quantity
+	pantryItem.Unit = updatePantryItem.Unit
+	pantryItem.MinQuantity = updatePantryItem.MinQuantity
+	if err := c.pantryRepo.UpsertPantryItem(pantryItem); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not update pantry item for item with ID %v: %w", itemID, err))
+	}
+
+	updatedPantryItem, err := c.pantryRepo.GetPantryItem(user.HouseholdID, itemID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get updated pantry item for item with ID %v: %w", itemID, err))
+	}
+	c.restock(user, updatedPantryItem)
+
+	return &updatedPantryItem, nil
+}
+
+func (c *PantryController) ConsumeItem(user *user.AppUser, itemID uuid.UUID, consumePantryItem *ConsumePantryItem) (*PantryItem, *controller.ControllerError) {
+	if consumePantryItem.Quantity <= 0 {
+		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("quantity must be positive"))
+	}
+
+	pantryItem, err := c.pantryRepo.GetPantryItem(user.HouseholdID, itemID)
+	if err != nil {
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("pantry item for item with ID %v not found", itemID))
+	}
+
+	consumeUnit := pantryItem.Unit
+	if consumePantryItem.Unit != "" {
+		consumeUnit = consumePantryItem.Unit
+	}
+	if _, ok := unit.Convert(consumePantryItem.Quantity, consumeUnit, pantryItem.Unit); !ok {
+		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("cannot convert %v to %v", unit.Normalize(consumeUnit), pantryItem.Unit))
+	}
+
+	if err := c.pantryRepo.AddStock(user.HouseholdID, itemID, -consumePantryItem.Quantity, consumeUnit); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not consume item with ID %v: %w", itemID, err))
+	}
+
+	updatedPantryItem, err := c.pantryRepo.GetPantryItem(user.HouseholdID, itemID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get updated pantry item for item with ID %v: %w", itemID, err))
+	}
+	c.restock(user, updatedPantryItem)
+
+	return &updatedPantryItem, nil
+}
+
+// restock adds the missing quantity of the item to the default list, if the pantry is running low on it,
+// and it is not already on the list. Failing to restock is not fatal, the stock has already been updated
+func (c *PantryController) restock(user *user.AppUser, pantryItem PantryItem) {
+	if !pantryItem.IsBelowMinimum() {
+		return
+	}
+
+	defaultList, cErr := c.listController.GetDefaultList(user)
+	if cErr != nil {
+		zap.S().Infow("Could not restock pantry item, no default list", "householdID", user.HouseholdID, "itemID", pantryItem.ItemID, "error", cErr.Err)
+		return
+	}
+
+	foundList, cErr := c.listController.GetList(user, defaultList.ListID)
+	if cErr != nil {
+		zap.S().Warnw("Could not restock pantry item", "householdID", user.HouseholdID, "itemID", pantryItem.ItemID, "error", cErr.Err)
+		return
+	}
+	for _, listItem := range foundList.Items {
+		if listItem.ItemID == pantryItem.ItemID && !listItem.Crossed {
+			return
+		}
+	}
+
+	missing := list.ItemQuantity{
+		ItemID:   pantryItem.ItemID,
+		Quantity: *pantryItem.MinQuantity - pantryItem.Quantity,
+		Unit:     pantryItem.Unit,
+	}
+	if _, cErr := c.listController.MergeItemsIntoList(user, foundList.ID, []list.ItemQuantity{missing}); cErr != nil {
+		zap.S().Warnw("Could not restock pantry item", "householdID", user.HouseholdID, "itemID", pantryItem.ItemID, "error", cErr.Err)
+	}
+}
@@ -0,0 +1,39 @@
+package pantry
+
+import (
+	"ShoppingList-Backend/internal/pkg/item"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PantryItem struct {
+	ID          uuid.UUID  `db:"id" json:"id"`
+	CreatedAt   time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt   *time.Time `db:"updated_at" json:"updatedAt"`
+	HouseholdID uuid.UUID  `db:"household_id" json:"householdId"`
+
+	ItemID   uuid.UUID `db:"item_id" json:"itemId"`
+	Item     item.Item `db:"-" json:"item"`
+	Quantity float64   `db:"quantity" json:"quantity"`
+	Unit     string    `db:"unit" json:"unit"`
+	// When the quantity falls below MinQuantity, the item is added to the default list
+	MinQuantity *float64 `db:"min_quantity" json:"minQuantity"`
+}
+
+// IsBelowMinimum returns true if the pantry is running low on the item
+func (p *PantryItem) IsBelowMinimum() bool {
+	return p.MinQuantity != nil && p.Quantity < *p.MinQuantity
+}
+
+type UpdatePantryItem struct {
+	Quantity    float64  `json:"quantity"`
+	Unit        string   `json:"unit"`
+	MinQuantity *float64 `json:"minQuantity"`
+}
+
+type ConsumePantryItem struct {
+	Quantity float64 `json:"quantity"`
+	// Unit defaults to the unit of the pantry item
+	Unit string `json:"unit"`
+}
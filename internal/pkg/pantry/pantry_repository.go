@@ -0,0 +1,131 @@
+package pantry
+
+import (
+	"ShoppingList-Backend/internal/pkg/item"
+	"ShoppingList-Backend/internal/pkg/unit"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type PantryRepository struct {
+	DB *sqlx.DB
+}
+
+func (q *PantryRepository) populateWithItems(pantryItems []PantryItem) error {
+	if len(pantryItems) == 0 {
+		return nil
+	}
+
+	itemIds := make([]uuid.UUID, 0, len(pantryItems))
+	for _, pantryItem := range pantryItems {
+		itemIds = append(itemIds, pantryItem.ItemID)
+	}
+	items := []item.Item{}
+	query, args, err := sqlx.In(`SELECT * FROM items WHERE id IN (?)`, itemIds)
+	if err != nil {
+		return err
+	}
+	if err := q.DB.Select(&items, q.DB.Rebind(query), args...); err != nil {
+		return err
+	}
+
+	itemsById := make(map[uuid.UUID]item.Item)
+	for _, item := range items {
+		itemsById[item.ID] = item
+	}
+	for i, pantryItem := range pantryItems {
+		pantryItems[i].Item = itemsById[pantryItem.ItemID]
+	}
+	return nil
+}
+
+func (q *PantryRepository) GetPantryItems(householdID uuid.UUID) ([]PantryItem, error) {
+	pantryItems := []PantryItem{}
+	query := `SELECT * FROM pantry_items WHERE household_id = $1`
+	err := q.DB.Select(&pantryItems, query, householdID)
+	if err != nil {
+		return pantryItems, err
+	}
+
+	err = q.populateWithItems(pantryItems)
+	return pantryItems, err
+}
+
+func (q *PantryRepository) GetPantryItem(householdID uuid.UUID, itemID uuid.UUID) (PantryItem, error) {
+	pantryItem := PantryItem{}
+	query := `SELECT * FROM pantry_items WHERE household_id = $1 AND item_id = $2`
+	err := q.DB.Get(&pantryItem, query, householdID, itemID)
+	if err != nil {
+		return pantryItem, err
+	}
+
+	pantryItems := []PantryItem{pantryItem}
+	err = q.populateWithItems(pantryItems)
+	return pantryItems[0], err
+}
+
+func (q *PantryRepository) UpsertPantryItem(pantryItem PantryItem) error {
+	query := `INSERT INTO pantry_items (id, household_id, item_id, quantity, unit, min_quantity) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (household_id, item_id) DO UPDATE SET updated_at = NOW(), quantity = $4, unit = $5, min_quantity = $6`
+	_, err := q.DB.Exec(query, pantryItem.ID, pantryItem.HouseholdID, pantryItem.ItemID, pantryItem.Quantity, unit.Normalize(pantryItem.Unit), pantryItem.MinQuantity)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// AddStock adds the quantity (which may be negative) to the stock of the item, converted to the unit of the pantry item.
+// Stock never goes below zero.
+func (q *PantryRepository) AddStock(householdID uuid.UUID, itemID uuid.UUID, quantity float64, quantityUnit string) error {
+	tx, err := q.DB.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The first stocking of an item inserts the row. SELECT ... FOR UPDATE cannot lock a row that does not exist yet,
+	// so if another transaction inserts it first, the insert does nothing and the quantity is added to that row below
+	insertQuery := `INSERT INTO pantry_items (id, household_id, item_id, quantity, unit) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (household_id, item_id) DO NOTHING`
+	result, err := tx.Exec(insertQuery, uuid.New(), householdID, itemID, math.Max(quantity, 0), unit.Normalize(quantityUnit))
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 1 {
+		return tx.Commit()
+	}
+
+	pantryItem := PantryItem{}
+	err = tx.Get(&pantryItem, `SELECT * FROM pantry_items WHERE household_id = $1 AND item_id = $2 FOR UPDATE`, householdID, itemID)
+	if err != nil {
+		return err
+	}
+
+	converted, ok := unit.Convert(quantity, quantityUnit, pantryItem.Unit)
+	if !ok {
+		if pantryItem.Quantity > 0 {
+			return fmt.Errorf("cannot convert %v to %v", unit.Normalize(quantityUnit), pantryItem.Unit)
+		}
+		// Nothing left in the old unit, so just switch to the new one
+		converted = quantity
+		pantryItem.Unit = unit.Normalize(quantityUnit)
+	}
+
+	updateQuery := `UPDATE pantry_items SET updated_at = NOW(), quantity = $2, unit = $3 WHERE id = $1`
+	if _, err := tx.Exec(updateQuery, pantryItem.ID, math.Max(pantryItem.Quantity+converted, 0), pantryItem.Unit); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (q *PantryRepository) DeletePantryItem(pantryItem PantryItem) error {
+	query := `DELETE FROM pantry_items WHERE id = $1`
+	_, err := q.DB.Exec(query, pantryItem.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package price
+
+import (
+	"ShoppingList-Backend/internal/pkg/list"
+	"ShoppingList-Backend/internal/pkg/unit"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Cost returns the cost of the quantity at the price, rounded to the nearest minor unit.
+// ok is false if the unit is not compatible with the unit of the price
+func Cost(price Price, quantity float64, quantityUnit string) (int64, bool) {
+	converted, ok := unit.Convert(quantity, quantityUnit, price.Unit)
+	if !ok {
+		return 0, false
+	}
+	return int64(math.Round(converted * float64(price.Amount))), true
+}
+
+func totals(amounts map[string]int64) []Money {
+	money := make([]Money, 0, len(amounts))
+	for currency, amount := range amounts {
+		money = append(money, Money{Amount: amount, Currency: currency})
+	}
+	sort.Slice(money, func(i, j int) bool {
+		return money[i].Currency < money[j].Currency
+	})
+	return money
+}
+
+// Estimate sums up the costs of the list items using the prices by item ID
+func Estimate(listItems []list.ListItem, prices map[uuid.UUID]Price) ListCosts {
+	estimated := make(map[string]int64)
+	spent := make(map[string]int64)
+	unpriced := []uuid.UUID{}
+
+	for _, listItem := range listItems {
+		price, ok := prices[listItem.ItemID]
+		if !ok {
+			unpriced = append(unpriced, listItem.ItemID)
+			continue
+		}
+		cost, ok := Cost(price, listItem.Quantity, listItem.Unit)
+		if !ok {
+			unpriced = append(unpriced, listItem.ItemID)
+			continue
+		}
+		if listItem.Crossed {
+			spent[price.Currency] += cost
+		} else {
+			estimated[price.Currency] += cost
+		}
+	}
+
+	return ListCosts{
+		Estimated:       totals(estimated),
+		Spent:           totals(spent),
+		UnpricedItemIDs: unpriced,
+	}
+}
+
+// EstimateCost estimates the cost of the list items in the currency, using the latest price of each item.
+// Prices at the store are preferred, as in GetListWithCosts
+func (q *PriceRepository) EstimateCost(householdID uuid.UUID, listItems []list.ListItem, currency string, store string) (int64, error) {
+	itemIDs := make([]uuid.UUID, 0, len(listItems))
+	for _, listItem := range listItems {
+		itemIDs = append(itemIDs, listItem.ItemID)
+	}
+	prices, err := q.GetLatestPrices(householdID, itemIDs, store)
+	if err != nil {
+		return 0, err
+	}
+
+	costs := Estimate(listItems, prices)
+	var total int64
+	for _, money := range append(costs.Estimated, costs.Spent...) {
+		if money.Currency == currency {
+			total += money.Amount
+		}
+	}
+	return total, nil
+}
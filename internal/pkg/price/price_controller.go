@@ -0,0 +1,133 @@
+package price
+
+import (
+	"ShoppingList-Backend/internal/pkg/controller"
+	"ShoppingList-Backend/internal/pkg/item"
+	"ShoppingList-Backend/internal/pkg/list"
+	"ShoppingList-Backend/internal/pkg/unit"
+	"ShoppingList-Backend/internal/pkg/user"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PriceController struct {
+	priceRepo      *PriceRepository
+	itemRepo       *item.ItemRepository
+	listController *list.ListController
+	preferences    list.UserPreferences
+}
+
+func NewPriceController(priceRepo *PriceRepository, itemRepo *item.ItemRepository, listController *list.ListController, preferences list.UserPreferences) *PriceController {
+	return &PriceController{
+		priceRepo:      priceRepo,
+		itemRepo:       itemRepo,
+		listController: listController,
+		preferences:    preferences,
+	}
+}
+
+func (c *PriceController) getItem(user *user.AppUser, itemID uuid.UUID) (item.Item, *controller.ControllerError) {
+	foundItem, err := c.itemRepo.GetItem(itemID, user.HouseholdID)
+	if err != nil {
+		return foundItem, controller.CError(http.StatusNotFound, fmt.Errorf("item with ID %v not found", itemID))
+	}
+	return foundItem, nil
+}
+
+func (c *PriceController) GetPrices(user *user.AppUser, itemID uuid.UUID, store string) ([]Price, *controller.ControllerError) {
+	if _, cErr := c.getItem(user, itemID); cErr != nil {
+		return nil, cErr
+	}
+
+	prices, err := c.priceRepo.GetPrices(user.HouseholdID, itemID, store)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get prices of item with ID %v: %w", itemID, err))
+	}
+	return prices, nil
+}
+
+func (c *PriceController) CreatePrice(user *user.AppUser, itemID uuid.UUID, addPrice *AddPrice) (*Price, *controller.ControllerError) {
+	if err := addPrice.Validate(); err != nil {
+		return nil, controller.CError(http.StatusBadRequest, err)
+	}
+	if _, cErr := c.getItem(user, itemID); cErr != nil {
+		return nil, cErr
+	}
+
+	recordedAt := time.Now().UTC().Truncate(24 * time.Hour)
+	if addPrice.Date != "" {
+		date, err := time.Parse(DateFormat, addPrice.Date)
+		if err != nil {
+			return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("could not parse date %v: %w", addPrice.Date, err))
+		}
+		recordedAt = date
+	}
+
+	priceToCreate := Price{
+		ID:          uuid.New(),
+		OwnerID:     user.ID,
+		HouseholdID: user.HouseholdID,
+		ItemID:      itemID,
+		Store:       addPrice.Store,
+		Amount:      addPrice.Amount,
+		Currency:    addPrice.Currency,
+		Unit:        unit.Normalize(addPrice.Unit),
+		RecordedAt:  recordedAt,
+	}
+	priceID, err := c.priceRepo.CreatePrice(priceToCreate)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not create price: %w", err))
+	}
+
+	createdPrice, err := c.priceRepo.GetPrice(priceID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get created price with ID %v: %w", priceID, err))
+	}
+	return &createdPrice, nil
+}
+
+func (c *PriceController) DeletePrice(user *user.AppUser, itemID uuid.UUID, priceID uuid.UUID) *controller.ControllerError {
+	foundPrice, err := c.priceRepo.GetPrice(priceID)
+	if err != nil || foundPrice.HouseholdID != user.HouseholdID || foundPrice.ItemID != itemID {
+		return controller.CError(http.StatusNotFound, fmt.Errorf("price with ID %v not found", priceID))
+	}
+
+	if err := c.priceRepo.DeletePrice(foundPrice); err != nil {
+		return controller.CError(http.StatusInternalServerError, fmt.Errorf("could not delete price with ID %v: %w", priceID, err))
+	}
+	return nil
+}
+
+// GetListWithCosts gets the list, and if includeCosts is true, the estimated and spent costs using the latest price of each item.
+// Prices from the preferred store of the user are used when there are any
+func (c *PriceController) GetListWithCosts(user *user.AppUser, listID uuid.UUID, includeCosts bool) (*ListWithCosts, *controller.ControllerError) {
+	foundList, cErr := c.listController.GetList(user, listID)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	listWithCosts := &ListWithCosts{List: *foundList}
+	if !includeCosts {
+		return listWithCosts, nil
+	}
+
+	itemIDs := make([]uuid.UUID, 0, len(foundList.Items))
+	for _, listItem := range foundList.Items {
+		itemIDs = append(itemIDs, listItem.ItemID)
+	}
+	preferences, err := c.preferences.GetPreferences(user.ID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get preferences: %w", err))
+	}
+	prices, err := c.priceRepo.GetLatestPrices(foundList.HouseholdID, itemIDs, preferences.PreferredStore)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get prices for list with ID %v: %w", listID, err))
+	}
+
+	costs := Estimate(foundList.Items, prices)
+	listWithCosts.Costs = &costs
+	return listWithCosts, nil
+}
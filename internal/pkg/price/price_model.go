@@ -0,0 +1,75 @@
+package price
+
+import (
+	"ShoppingList-Backend/internal/pkg/list"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const DateFormat = "2006-01-02"
+
+var currencyRegexp = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Money is an amount in the minor unit of the currency, e.g. cents
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type Price struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	// OwnerID is the user that recorded the price
+	OwnerID     string    `db:"owner_id" json:"ownerId"`
+	HouseholdID uuid.UUID `db:"household_id" json:"householdId"`
+
+	ItemID uuid.UUID `db:"item_id" json:"itemId"`
+	Store  string    `db:"store" json:"store"`
+	// Amount is in minor units of the currency, per unit of the item
+	Amount     int64     `db:"amount" json:"amount"`
+	Currency   string    `db:"currency" json:"currency"`
+	Unit       string    `db:"unit" json:"unit"`
+	RecordedAt time.Time `db:"recorded_at" json:"recordedAt"`
+}
+
+type AddPrice struct {
+	// Amount in minor units of the currency, e.g. 1295 for 12.95
+	Amount int64 `json:"amount"`
+	// ISO 4217 currency code, e.g. DKK
+	Currency string `json:"currency"`
+	// The unit the price is for, e.g. kg. Empty means per piece
+	Unit  string `json:"unit"`
+	Store string `json:"store"`
+	// Date the price was seen (YYYY-MM-DD), defaults to today
+	Date string `json:"date"`
+}
+
+func (p *AddPrice) Validate() error {
+	p.Currency = strings.ToUpper(strings.TrimSpace(p.Currency))
+	p.Store = strings.TrimSpace(p.Store)
+	if p.Amount < 0 {
+		return fmt.Errorf("amount must not be negative")
+	}
+	if !currencyRegexp.MatchString(p.Currency) {
+		return fmt.Errorf("currency must be a 3 letter ISO 4217 code, got %v", p.Currency)
+	}
+	return nil
+}
+
+type ListCosts struct {
+	// Estimated cost of the uncrossed items, per currency
+	Estimated []Money `json:"estimated"`
+	// Spent on the crossed items, per currency
+	Spent []Money `json:"spent"`
+	// Items without a (compatible) price, which are not part of the totals
+	UnpricedItemIDs []uuid.UUID `json:"unpricedItemIds"`
+}
+
+type ListWithCosts struct {
+	list.List
+	Costs *ListCosts `json:"costs,omitempty"`
+}
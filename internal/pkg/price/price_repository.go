@@ -0,0 +1,69 @@
+package price
+
+import (
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type PriceRepository struct {
+	DB *sqlx.DB
+}
+
+// GetPrices returns the price history of the item, newest first. If store is not empty, only prices from that store are returned
+func (q *PriceRepository) GetPrices(householdID uuid.UUID, itemID uuid.UUID, store string) ([]Price, error) {
+	prices := []Price{}
+	query := `SELECT * FROM prices WHERE household_id = $1 AND item_id = $2 AND ($3::text = '' OR lower(store) = lower($3::text))
+		ORDER BY recorded_at DESC, created_at DESC`
+	err := q.DB.Select(&prices, query, householdID, itemID, store)
+	return prices, err
+}
+
+// GetLatestPrices returns the most recently recorded price of each of the items, by item ID.
+// If preferredStore is set, prices from that store take precedence over newer prices from other stores
+func (q *PriceRepository) GetLatestPrices(householdID uuid.UUID, itemIDs []uuid.UUID, preferredStore string) (map[uuid.UUID]Price, error) {
+	pricesByItemID := make(map[uuid.UUID]Price)
+	if len(itemIDs) == 0 {
+		return pricesByItemID, nil
+	}
+
+	prices := []Price{}
+	query, args, err := sqlx.In(`SELECT DISTINCT ON (item_id) * FROM prices WHERE household_id = ? AND item_id IN (?)
+		ORDER BY item_id, (? <> '' AND lower(store) = lower(?)) DESC, recorded_at DESC, created_at DESC`,
+		householdID, itemIDs, preferredStore, preferredStore)
+	if err != nil {
+		return pricesByItemID, err
+	}
+	if err := q.DB.Select(&prices, q.DB.Rebind(query), args...); err != nil {
+		return pricesByItemID, err
+	}
+
+	for _, price := range prices {
+		pricesByItemID[price.ItemID] = price
+	}
+	return pricesByItemID, nil
+}
+
+func (q *PriceRepository) GetPrice(id uuid.UUID) (Price, error) {
+	price := Price{}
+	query := `SELECT * FROM prices WHERE id = $1`
+	err := q.DB.Get(&price, query, id)
+	return price, err
+}
+
+func (q *PriceRepository) CreatePrice(price Price) (uuid.UUID, error) {
+	query := `INSERT INTO prices (id, owner_id, household_id, item_id, store, amount, currency, unit, recorded_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := q.DB.Exec(query, price.ID, price.OwnerID, price.HouseholdID, price.ItemID, price.Store, price.Amount, price.Currency, price.Unit, price.RecordedAt)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return price.ID, nil
+}
+
+func (q *PriceRepository) DeletePrice(price Price) error {
+	query := `DELETE FROM prices WHERE id = $1`
+	_, err := q.DB.Exec(query, price.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
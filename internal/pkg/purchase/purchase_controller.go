@@ -0,0 +1,33 @@
+package purchase
+
+import (
+	"ShoppingList-Backend/internal/pkg/controller"
+	"ShoppingList-Backend/internal/pkg/user"
+	"fmt"
+	"net/http"
+)
+
+type PurchaseController struct {
+	purchaseRepo *PurchaseRepository
+}
+
+func NewPurchaseController(purchaseRepo *PurchaseRepository) *PurchaseController {
+	return &PurchaseController{
+		purchaseRepo: purchaseRepo,
+	}
+}
+
+func (c *PurchaseController) GetItemStats(user *user.AppUser, filter StatsFilter) ([]ItemStats, *controller.ControllerError) {
+	if user == nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("nil user"))
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("from (%v) must be before to (%v)", filter.From, filter.To))
+	}
+
+	stats, err := c.purchaseRepo.GetItemStats(user.HouseholdID, filter)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get item stats: %w", err))
+	}
+	return stats, nil
+}
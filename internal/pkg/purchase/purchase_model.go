@@ -0,0 +1,37 @@
+package purchase
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Purchase struct {
+	ID          uuid.UUID  `db:"id" json:"id"`
+	CreatedAt   time.Time  `db:"created_at" json:"createdAt"`
+	PurchasedAt time.Time  `db:"purchased_at" json:"purchasedAt"`
+	ItemID      uuid.UUID  `db:"item_id" json:"itemId"`
+	ListID      *uuid.UUID `db:"list_id" json:"listId"`
+	UserID      string     `db:"user_id" json:"userId"`
+	HouseholdID uuid.UUID  `db:"household_id" json:"householdId"`
+	Quantity    float64    `db:"quantity" json:"quantity"`
+}
+
+type ItemStats struct {
+	ItemID           uuid.UUID `db:"item_id" json:"itemId"`
+	ItemName         string    `db:"item_name" json:"itemName"`
+	PurchaseCount    int       `db:"purchase_count" json:"purchaseCount"`
+	TotalQuantity    float64   `db:"total_quantity" json:"totalQuantity"`
+	FirstPurchasedAt time.Time `db:"first_purchased_at" json:"firstPurchasedAt"`
+	LastPurchasedAt  time.Time `db:"last_purchased_at" json:"lastPurchasedAt"`
+	// AverageIntervalDays is nil when the item has only been bought once
+	AverageIntervalDays *float64 `db:"average_interval_days" json:"averageIntervalDays"`
+	// IntervalStddevDays is nil when the item has been bought less than three times
+	IntervalStddevDays *float64 `db:"interval_stddev_days" json:"intervalStddevDays"`
+}
+
+type StatsFilter struct {
+	From   *time.Time
+	To     *time.Time
+	ListID *uuid.UUID
+}
@@ -0,0 +1,69 @@
+package purchase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type PurchaseRepository struct {
+	DB *sqlx.DB
+}
+
+func (q *PurchaseRepository) GetItemStats(householdID uuid.UUID, filter StatsFilter) ([]ItemStats, error) {
+	stats := []ItemStats{}
+
+	conditions := []string{"household_id = $1"}
+	args := []interface{}{householdID}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("purchased_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("purchased_at < $%d", len(args)))
+	}
+	if filter.ListID != nil {
+		args = append(args, *filter.ListID)
+		conditions = append(conditions, fmt.Sprintf("list_id = $%d", len(args)))
+	}
+
+	query := `WITH filtered AS (
+			SELECT item_id, purchased_at, quantity,
+				EXTRACT(EPOCH FROM purchased_at - LAG(purchased_at) OVER (PARTITION BY item_id ORDER BY purchased_at)) / 86400 AS interval_days
+			FROM purchases
+			WHERE ` + strings.Join(conditions, " AND ") + `
+		)
+		SELECT filtered.item_id, items.name AS item_name,
+			COUNT(*) AS purchase_count,
+			SUM(filtered.quantity) AS total_quantity,
+			MIN(filtered.purchased_at) AS first_purchased_at,
+			MAX(filtered.purchased_at) AS last_purchased_at,
+			AVG(filtered.interval_days) AS average_interval_days,
+			STDDEV_SAMP(filtered.interval_days) AS interval_stddev_days
+		FROM filtered
+		JOIN items ON items.id = filtered.item_id
+		GROUP BY filtered.item_id, items.name
+		ORDER BY purchase_count DESC, last_purchased_at DESC`
+
+	err := q.DB.Select(&stats, query, args...)
+	if err != nil {
+		return stats, err
+	}
+
+	return stats, nil
+}
+
+// GetPurchasingHouseholdIDs returns the IDs of households that have bought something since the given time
+func (q *PurchaseRepository) GetPurchasingHouseholdIDs(since time.Time) ([]uuid.UUID, error) {
+	householdIDs := []uuid.UUID{}
+	query := `SELECT DISTINCT household_id FROM purchases WHERE purchased_at >= $1`
+	err := q.DB.Select(&householdIDs, query, since)
+	if err != nil {
+		return householdIDs, err
+	}
+	return householdIDs, nil
+}
@@ -0,0 +1,155 @@
+package recipe
+
+import (
+	"ShoppingList-Backend/internal/pkg/controller"
+	"ShoppingList-Backend/internal/pkg/item"
+	"ShoppingList-Backend/internal/pkg/list"
+	"ShoppingList-Backend/internal/pkg/user"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type RecipeController struct {
+	recipeRepo     *RecipeRepository
+	itemRepo       *item.ItemRepository
+	listController *list.ListController
+}
+
+func NewRecipeController(recipeRepo *RecipeRepository, itemRepo *item.ItemRepository, listController *list.ListController) *RecipeController {
+	return &RecipeController{
+		recipeRepo:     recipeRepo,
+		itemRepo:       itemRepo,
+		listController: listController,
+	}
+}
+
+func (c *RecipeController) GetRecipes(user *user.AppUser) ([]Recipe, *controller.ControllerError) {
+	recipes, err := c.recipeRepo.GetRecipes(user.HouseholdID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get recipes: %w", err))
+	}
+	return recipes, nil
+}
+
+func (c *RecipeController) GetRecipe(user *user.AppUser, recipeID uuid.UUID) (*Recipe, *controller.ControllerError) {
+	recipe, err := c.recipeRepo.GetRecipe(recipeID)
+	if err != nil || recipe.HouseholdID != user.HouseholdID {
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("recipe with ID %v not found", recipeID))
+	}
+	return &recipe, nil
+}
+
+// applyAddRecipe validates the request, and applies it to the recipe
+func (c *RecipeController) applyAddRecipe(user *user.AppUser, recipe *Recipe, addRecipe *AddRecipe) *controller.ControllerError {
+	if err := addRecipe.Validate(); err != nil {
+		return controller.CError(http.StatusBadRequest, err)
+	}
+
+	ingredients := make([]Ingredient, 0, len(addRecipe.Ingredients))
+	for _, addIngredient := range addRecipe.Ingredients {
+		if _, err := c.itemRepo.GetItem(addIngredient.ItemID, user.HouseholdID); err != nil {
+			return controller.CError(http.StatusNotFound, fmt.Errorf("item with ID %v not found", addIngredient.ItemID))
+		}
+		ingredients = append(ingredients, Ingredient{
+			ItemID:   addIngredient.ItemID,
+			Quantity: addIngredient.Quantity,
+			Unit:     addIngredient.Unit,
+		})
+	}
+
+	recipe.Name = addRecipe.Name
+	recipe.Servings = addRecipe.Servings
+	recipe.Ingredients = ingredients
+	return nil
+}
+
+func (c *RecipeController) CreateRecipe(user *user.AppUser, addRecipe *AddRecipe) (*Recipe, *controller.ControllerError) {
+	recipeToCreate := Recipe{
+		ID:          uuid.New(),
+		OwnerID:     user.ID,
+		HouseholdID: user.HouseholdID,
+	}
+	if cErr := c.applyAddRecipe(user, &recipeToCreate, addRecipe); cErr != nil {
+		return nil, cErr
+	}
+
+	recipeID, err := c.recipeRepo.CreateRecipe(recipeToCreate)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not create recipe: %w", err))
+	}
+
+	createdRecipe, err := c.recipeRepo.GetRecipe(recipeID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get created recipe with ID %v: %w", recipeID, err))
+	}
+
+	return &createdRecipe, nil
+}
+
+func (c *RecipeController) UpdateRecipe(user *user.AppUser, recipeID uuid.UUID, updateRecipe *AddRecipe) (*Recipe, *controller.ControllerError) {
+	foundRecipe, cErr := c.GetRecipe(user, recipeID)
+	if cErr != nil {
+		return nil, cErr
+	}
+	if cErr := c.applyAddRecipe(user, foundRecipe, updateRecipe); cErr != nil {
+		return nil, cErr
+	}
+
+	if err := c.recipeRepo.UpdateRecipe(*foundRecipe); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not update recipe with ID %v: %w", recipeID, err))
+	}
+
+	updatedRecipe, err := c.recipeRepo.GetRecipe(recipeID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get updated recipe with ID %v: %w", recipeID, err))
+	}
+
+	return &updatedRecipe, nil
+}
+
+func (c *RecipeController) DeleteRecipe(user *user.AppUser, recipeID uuid.UUID) *controller.ControllerError {
+	foundRecipe, cErr := c.GetRecipe(user, recipeID)
+	if cErr != nil {
+		return cErr
+	}
+
+	if err := c.recipeRepo.DeleteRecipe(*foundRecipe); err != nil {
+		return controller.CError(http.StatusInternalServerError, fmt.Errorf("could not delete recipe with ID %v: %w", recipeID, err))
+	}
+
+	return nil
+}
+
+// ScaledIngredients returns the ingredients of the recipe scaled to the given number of servings
+func ScaledIngredients(recipe Recipe, servings int) []list.ItemQuantity {
+	factor := float64(servings) / float64(recipe.Servings)
+	itemQuantities := make([]list.ItemQuantity, 0, len(recipe.Ingredients))
+	for _, ingredient := range recipe.Ingredients {
+		itemQuantities = append(itemQuantities, list.ItemQuantity{
+			ItemID:   ingredient.ItemID,
+			Quantity: ingredient.Quantity * factor,
+			Unit:     ingredient.Unit,
+		})
+	}
+	return itemQuantities
+}
+
+// AddRecipeToList adds the ingredients of the recipe to the list, merging them into existing uncrossed list items
+func (c *RecipeController) AddRecipeToList(user *user.AppUser, recipeID uuid.UUID, addRecipeToList *AddRecipeToList) (*list.List, *controller.ControllerError) {
+	foundRecipe, cErr := c.GetRecipe(user, recipeID)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	servings := foundRecipe.Servings
+	if addRecipeToList.Servings != nil {
+		if *addRecipeToList.Servings <= 0 {
+			return nil, controller.CError(http.StatusBadRequest, fmt.Errorf("servings must be positive"))
+		}
+		servings = *addRecipeToList.Servings
+	}
+
+	return c.listController.MergeItemsIntoList(user, addRecipeToList.ListID, ScaledIngredients(*foundRecipe, servings))
+}
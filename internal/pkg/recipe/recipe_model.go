@@ -0,0 +1,66 @@
+package recipe
+
+import (
+	"ShoppingList-Backend/internal/pkg/item"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Recipe struct {
+	ID        uuid.UUID  `db:"id" json:"id"`
+	CreatedAt time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt *time.Time `db:"updated_at" json:"updatedAt"`
+	DeletedAt *time.Time `db:"deleted_at" json:"deletedAt"`
+	// OwnerID is the user that created the recipe
+	OwnerID     string    `db:"owner_id" json:"ownerId"`
+	HouseholdID uuid.UUID `db:"household_id" json:"householdId"`
+
+	Name        string       `db:"name" json:"name"`
+	Servings    int          `db:"servings" json:"servings"`
+	Ingredients []Ingredient `db:"-" json:"ingredients"`
+}
+
+type Ingredient struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	RecipeID  uuid.UUID `db:"recipe_id" json:"recipeId"`
+	ItemID    uuid.UUID `db:"item_id" json:"itemId"`
+	Item      item.Item `db:"-" json:"item"`
+	Quantity  float64   `db:"quantity" json:"quantity"`
+	Unit      string    `db:"unit" json:"unit"`
+}
+
+type AddRecipe struct {
+	Name        string          `json:"name"`
+	Servings    int             `json:"servings"`
+	Ingredients []AddIngredient `json:"ingredients"`
+}
+
+type AddIngredient struct {
+	ItemID   uuid.UUID `json:"itemId"`
+	Quantity float64   `json:"quantity"`
+	Unit     string    `json:"unit"`
+}
+
+type AddRecipeToList struct {
+	ListID uuid.UUID `json:"listId"`
+	// Servings to shop for. Defaults to the servings of the recipe
+	Servings *int `json:"servings"`
+}
+
+func (a *AddRecipe) Validate() error {
+	if a.Name == "" {
+		return errors.New("name must not be empty")
+	}
+	if a.Servings <= 0 {
+		return errors.New("servings must be positive")
+	}
+	for _, ingredient := range a.Ingredients {
+		if ingredient.Quantity <= 0 {
+			return errors.New("ingredient quantity must be positive")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package recipe
+
+import (
+	"ShoppingList-Backend/internal/pkg/item"
+	"ShoppingList-Backend/internal/pkg/unit"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type RecipeRepository struct {
+	DB *sqlx.DB
+}
+
+func (q *RecipeRepository) populateWithIngredients(recipes []Recipe) error {
+	if len(recipes) == 0 {
+		return nil
+	}
+
+	recipeIds := make([]uuid.UUID, 0, len(recipes))
+	for _, recipe := range recipes {
+		recipeIds = append(recipeIds, recipe.ID)
+	}
+
+	ingredients := []Ingredient{}
+	query, args, err := sqlx.In(`SELECT * FROM recipe_ingredients WHERE recipe_id IN (?) ORDER BY created_at ASC`, recipeIds)
+	if err != nil {
+		return err
+	}
+	if err := q.DB.Select(&ingredients, q.DB.Rebind(query), args...); err != nil {
+		return err
+	}
+
+	itemsById := make(map[uuid.UUID]item.Item)
+	if len(ingredients) > 0 {
+		itemIds := make([]uuid.UUID, 0, len(ingredients))
+		for _, ingredient := range ingredients {
+			itemIds = append(itemIds, ingredient.ItemID)
+		}
+		items := []item.Item{}
+		query, args, err := sqlx.In(`SELECT * FROM items WHERE id IN (?)`, itemIds)
+		if err != nil {
+			return err
+		}
+		if err := q.DB.Select(&items, q.DB.Rebind(query), args...); err != nil {
+			return err
+		}
+		for _, item := range items {
+			itemsById[item.ID] = item
+		}
+	}
+
+	ingredientsByRecipeId := make(map[uuid.UUID][]Ingredient)
+	for _, ingredient := range ingredients {
+		ingredient.Item = itemsById[ingredient.ItemID]
+		ingredientsByRecipeId[ingredient.RecipeID] = append(ingredientsByRecipeId[ingredient.RecipeID], ingredient)
+	}
+	for i, recipe := range recipes {
+		recipes[i].Ingredients = ingredientsByRecipeId[recipe.ID]
+		if recipes[i].Ingredients == nil {
+			recipes[i].Ingredients = make([]Ingredient, 0)
+		}
+	}
+
+	return nil
+}
+
+func (q *RecipeRepository) GetRecipes(householdID uuid.UUID) ([]Recipe, error) {
+	recipes := []Recipe{}
+
+	query := `SELECT * FROM recipes WHERE household_id = $1 AND deleted_at IS NULL ORDER BY name ASC`
+	err := q.DB.Select(&recipes, query, householdID)
+	if err != nil {
+		return recipes, err
+	}
+
+	err = q.populateWithIngredients(recipes)
+	return recipes, err
+}
+
+func (q *RecipeRepository) GetRecipe(id uuid.UUID) (Recipe, error) {
+	recipe := Recipe{}
+
+	query := `SELECT * FROM recipes WHERE id = $1 AND deleted_at IS NULL`
+	err := q.DB.Get(&recipe, query, id)
+	if err != nil {
+		return recipe, err
+	}
+
+	recipes := []Recipe{recipe}
+	err = q.populateWithIngredients(recipes)
+	return recipes[0], err
+}
+
+func insertIngredients(tx *sqlx.Tx, recipe Recipe) error {
+	query := `INSERT INTO recipe_ingredients (id, recipe_id, item_id, quantity, unit) VALUES ($1, $2, $3, $4, $5)`
+	for _, ingredient := range recipe.Ingredients {
+		if _, err := tx.Exec(query, uuid.New(), recipe.ID, ingredient.ItemID, ingredient.Quantity, unit.Normalize(ingredient.Unit)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *RecipeRepository) CreateRecipe(recipe Recipe) (uuid.UUID, error) {
+	tx, err := q.DB.Beginx()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO recipes (id, owner_id, household_id, name, servings) VALUES ($1, $2, $3, $4, $5)`
+	if _, err := tx.Exec(query, recipe.ID, recipe.OwnerID, recipe.HouseholdID, recipe.Name, recipe.Servings); err != nil {
+		return uuid.Nil, err
+	}
+	if err := insertIngredients(tx, recipe); err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, err
+	}
+	return recipe.ID, nil
+}
+
+// UpdateRecipe updates the recipe and replaces its ingredients
+func (q *RecipeRepository) UpdateRecipe(recipe Recipe) error {
+	tx, err := q.DB.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE recipes SET updated_at = NOW(), name = $2, servings = $3 WHERE id = $1`
+	if _, err := tx.Exec(query, recipe.ID, recipe.Name, recipe.Servings); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM recipe_ingredients WHERE recipe_id = $1`, recipe.ID); err != nil {
+		return err
+	}
+	if err := insertIngredients(tx, recipe); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteRecipe soft-deletes the recipe, and deletes the meal plan entries of it, since they cannot be shown without it
+func (q *RecipeRepository) DeleteRecipe(recipe Recipe) error {
+	tx, err := q.DB.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM meal_plan_entries WHERE recipe_id = $1`, recipe.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE recipes SET deleted_at = NOW() WHERE id = $1`, recipe.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (q *RecipeRepository) DeleteRecipes(ownerID string) error {
+	query := `DELETE FROM recipes WHERE owner_id = $1`
+	_, err := q.DB.Exec(query, ownerID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package recurring
+
+import (
+	"ShoppingList-Backend/internal/pkg/controller"
+	"ShoppingList-Backend/internal/pkg/item"
+	"ShoppingList-Backend/internal/pkg/list"
+	"ShoppingList-Backend/internal/pkg/user"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RecurringController struct {
+	recurringRepo *RecurringRepository
+	itemRepo      *item.ItemRepository
+	listRepo      *list.ListRepository
+}
+
+func NewRecurringController(recurringRepo *RecurringRepository, itemRepo *item.ItemRepository, listRepo *list.ListRepository) *RecurringController {
+	return &RecurringController{
+		recurringRepo: recurringRepo,
+		itemRepo:      itemRepo,
+		listRepo:      listRepo,
+	}
+}
+
+func (c *RecurringController) GetRecurringItems(user *user.AppUser) ([]RecurringItem, *controller.ControllerError) {
+	recurringItems, err := c.recurringRepo.GetRecurringItems(user.HouseholdID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get recurring items: %w", err))
+	}
+	return recurringItems, nil
+}
+
+func (c *RecurringController) getRecurringItem(user *user.AppUser, recurringItemID uuid.UUID) (RecurringItem, *controller.ControllerError) {
+	recurringItem, err := c.recurringRepo.GetRecurringItem(recurringItemID)
+	if err != nil || recurringItem.HouseholdID != user.HouseholdID {
+		return recurringItem, controller.CError(http.StatusNotFound, fmt.Errorf("recurring item with ID %v not found", recurringItemID))
+	}
+	return recurringItem, nil
+}
+
+// applyAddRecurringItem validates the request, and applies it to the recurring item
+func (c *RecurringController) applyAddRecurringItem(user *user.AppUser, recurringItem *RecurringItem, addRecurringItem *AddRecurringItem) *controller.ControllerError {
+	if err := addRecurringItem.Validate(); err != nil {
+		return controller.CError(http.StatusBadRequest, err)
+	}
+
+	if _, err := c.itemRepo.GetItem(addRecurringItem.ItemID, user.HouseholdID); err != nil {
+		return controller.CError(http.StatusNotFound, fmt.Errorf("item with ID %v not found", addRecurringItem.ItemID))
+	}
+
+	if _, err := c.listRepo.GetList(addRecurringItem.ListID, user); err != nil {
+		return controller.CError(http.StatusNotFound, fmt.Errorf("list with ID %v not found: %w", addRecurringItem.ListID, err))
+	}
+
+	recurringItem.ItemID = addRecurringItem.ItemID
+	recurringItem.ListID = addRecurringItem.ListID
+	recurringItem.Cron = addRecurringItem.Cron
+	recurringItem.IntervalDays = addRecurringItem.IntervalDays
+	recurringItem.Quantity = 1
+	if addRecurringItem.Quantity != nil {
+		recurringItem.Quantity = *addRecurringItem.Quantity
+	}
+
+	nextRunAt, err := recurringItem.NextRun(time.Now())
+	if err != nil {
+		return controller.CError(http.StatusBadRequest, fmt.Errorf("could not schedule recurring item: %w", err))
+	}
+	recurringItem.NextRunAt = nextRunAt
+
+	return nil
+}
+
+func (c *RecurringController) CreateRecurringItem(user *user.AppUser, addRecurringItem *AddRecurringItem) (*RecurringItem, *controller.ControllerError) {
+	recurringItemToCreate := RecurringItem{
+		ID:          uuid.New(),
+		OwnerID:     user.ID,
+		HouseholdID: user.HouseholdID,
+	}
+	if cErr := c.applyAddRecurringItem(user, &recurringItemToCreate, addRecurringItem); cErr != nil {
+		return nil, cErr
+	}
+
+	recurringItemID, err := c.recurringRepo.CreateRecurringItem(recurringItemToCreate)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not create recurring item: %w", err))
+	}
+
+	createdRecurringItem, err := c.recurringRepo.GetRecurringItem(recurringItemID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get created recurring item with ID %v: %w", recurringItemID, err))
+	}
+
+	return &createdRecurringItem, nil
+}
+
+func (c *RecurringController) UpdateRecurringItem(user *user.AppUser, recurringItemID uuid.UUID, updateRecurringItem *AddRecurringItem) (*RecurringItem, *controller.ControllerError) {
+	foundRecurringItem, cErr := c.getRecurringItem(user, recurringItemID)
+	if cErr != nil {
+		return nil, cErr
+	}
+	if cErr := c.applyAddRecurringItem(user, &foundRecurringItem, updateRecurringItem); cErr != nil {
+		return nil, cErr
+	}
+
+	if err := c.recurringRepo.UpdateRecurringItem(foundRecurringItem); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not update recurring item with ID %v: %w", recurringItemID, err))
+	}
+
+	updatedRecurringItem, err := c.recurringRepo.GetRecurringItem(recurringItemID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get updated recurring item with ID %v: %w", recurringItemID, err))
+	}
+
+	return &updatedRecurringItem, nil
+}
+
+func (c *RecurringController) DeleteRecurringItem(user *user.AppUser, recurringItemID uuid.UUID) *controller.ControllerError {
+	foundRecurringItem, cErr := c.getRecurringItem(user, recurringItemID)
+	if cErr != nil {
+		return cErr
+	}
+
+	if err := c.recurringRepo.DeleteRecurringItem(foundRecurringItem); err != nil {
+		return controller.CError(http.StatusInternalServerError, fmt.Errorf("could not delete recurring item with ID %v: %w", recurringItemID, err))
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package recurring
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron"
+)
+
+type RecurringItem struct {
+	ID        uuid.UUID  `db:"id" json:"id"`
+	CreatedAt time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt *time.Time `db:"updated_at" json:"updatedAt"`
+	DeletedAt *time.Time `db:"deleted_at" json:"deletedAt"`
+	// OwnerID is the user that created the recurring item
+	OwnerID     string    `db:"owner_id" json:"ownerId"`
+	HouseholdID uuid.UUID `db:"household_id" json:"householdId"`
+
+	ItemID uuid.UUID `db:"item_id" json:"itemId"`
+	ListID uuid.UUID `db:"list_id" json:"listId"`
+	// Either Cron (standard 5 field cron expression) or IntervalDays is set
+	Cron         *string    `db:"cron" json:"cron"`
+	IntervalDays *int       `db:"interval_days" json:"intervalDays"`
+	Quantity     float64    `db:"quantity" json:"quantity"`
+	NextRunAt    time.Time  `db:"next_run_at" json:"nextRunAt"`
+	LastRunAt    *time.Time `db:"last_run_at" json:"lastRunAt"`
+}
+
+type AddRecurringItem struct {
+	ItemID       uuid.UUID `json:"itemId"`
+	ListID       uuid.UUID `json:"listId"`
+	Cron         *string   `json:"cron"`
+	IntervalDays *int      `json:"intervalDays"`
+	Quantity     *float64  `json:"quantity"`
+}
+
+// Validate checks that exactly one valid schedule is given
+func (a *AddRecurringItem) Validate() error {
+	if (a.Cron == nil) == (a.IntervalDays == nil) {
+		return errors.New("exactly one of cron and intervalDays must be set")
+	}
+	if a.Cron != nil {
+		if _, err := cron.ParseStandard(*a.Cron); err != nil {
+			return fmt.Errorf("invalid cron expression %q: %w", *a.Cron, err)
+		}
+	}
+	if a.IntervalDays != nil && *a.IntervalDays <= 0 {
+		return errors.New("intervalDays must be positive")
+	}
+	if a.Quantity != nil && *a.Quantity <= 0 {
+		return errors.New("quantity must be positive")
+	}
+	return nil
+}
+
+// NextRun returns the first time the item should be added to the list after the given time
+func (r *RecurringItem) NextRun(after time.Time) (time.Time, error) {
+	if r.Cron != nil {
+		schedule, err := cron.ParseStandard(*r.Cron)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return schedule.Next(after), nil
+	}
+	if r.IntervalDays != nil {
+		return after.AddDate(0, 0, *r.IntervalDays), nil
+	}
+	return time.Time{}, errors.New("recurring item has no schedule")
+}
@@ -0,0 +1,71 @@
+package recurring
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type RecurringRepository struct {
+	DB *sqlx.DB
+}
+
+func (q *RecurringRepository) GetRecurringItems(householdID uuid.UUID) ([]RecurringItem, error) {
+	recurringItems := []RecurringItem{}
+	query := `SELECT * FROM recurring_items WHERE household_id = $1 AND deleted_at IS NULL ORDER BY created_at ASC`
+	err := q.DB.Select(&recurringItems, query, householdID)
+	if err != nil {
+		return recurringItems, err
+	}
+	return recurringItems, nil
+}
+
+func (q *RecurringRepository) GetRecurringItem(id uuid.UUID) (RecurringItem, error) {
+	recurringItem := RecurringItem{}
+	query := `SELECT * FROM recurring_items WHERE id = $1 AND deleted_at IS NULL`
+	err := q.DB.Get(&recurringItem, query, id)
+	return recurringItem, err
+}
+
+// GetDueRecurringItems returns the recurring items that should have been added to their lists by now
+func (q *RecurringRepository) GetDueRecurringItems(now time.Time) ([]RecurringItem, error) {
+	recurringItems := []RecurringItem{}
+	query := `SELECT * FROM recurring_items WHERE next_run_at <= $1 AND deleted_at IS NULL ORDER BY next_run_at ASC`
+	err := q.DB.Select(&recurringItems, query, now)
+	if err != nil {
+		return recurringItems, err
+	}
+	return recurringItems, nil
+}
+
+func (q *RecurringRepository) CreateRecurringItem(recurringItem RecurringItem) (uuid.UUID, error) {
+	query := `INSERT INTO recurring_items (id, owner_id, household_id, item_id, list_id, cron, interval_days, quantity, next_run_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := q.DB.Exec(query, recurringItem.ID, recurringItem.OwnerID, recurringItem.HouseholdID, recurringItem.ItemID, recurringItem.ListID,
+		recurringItem.Cron, recurringItem.IntervalDays, recurringItem.Quantity, recurringItem.NextRunAt)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return recurringItem.ID, nil
+}
+
+func (q *RecurringRepository) UpdateRecurringItem(recurringItem RecurringItem) error {
+	query := `UPDATE recurring_items SET updated_at = NOW(), item_id = $2, list_id = $3, cron = $4, interval_days = $5, quantity = $6, next_run_at = $7
+		WHERE id = $1`
+	_, err := q.DB.Exec(query, recurringItem.ID, recurringItem.ItemID, recurringItem.ListID,
+		recurringItem.Cron, recurringItem.IntervalDays, recurringItem.Quantity, recurringItem.NextRunAt)
+	return err
+}
+
+func (q *RecurringRepository) SetRun(recurringItem RecurringItem, lastRunAt time.Time, nextRunAt time.Time) error {
+	query := `UPDATE recurring_items SET last_run_at = $2, next_run_at = $3 WHERE id = $1`
+	_, err := q.DB.Exec(query, recurringItem.ID, lastRunAt, nextRunAt)
+	return err
+}
+
+func (q *RecurringRepository) DeleteRecurringItem(recurringItem RecurringItem) error {
+	query := `UPDATE recurring_items SET deleted_at = NOW() WHERE id = $1`
+	_, err := q.DB.Exec(query, recurringItem.ID)
+	return err
+}
@@ -0,0 +1,72 @@
+package suggestion
+
+import (
+	"ShoppingList-Backend/internal/pkg/item"
+	"ShoppingList-Backend/internal/pkg/purchase"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Items that are this many intervals overdue are probably not bought anymore, so confidence decays after that
+const maxOverdueIntervals = 3.0
+
+// Generate suggests the catalog items whose average repurchase interval has elapsed since they were last bought.
+// Confidence grows with the number of observed intervals and how regular they are.
+func Generate(stats []purchase.ItemStats, catalog []item.Item, now time.Time) []Suggestion {
+	itemsByID := make(map[uuid.UUID]item.Item, len(catalog))
+	for _, catalogItem := range catalog {
+		itemsByID[catalogItem.ID] = catalogItem
+	}
+
+	suggestions := []Suggestion{}
+	for _, itemStats := range stats {
+		catalogItem, ok := itemsByID[itemStats.ItemID]
+		if !ok || itemStats.AverageIntervalDays == nil || *itemStats.AverageIntervalDays <= 0 {
+			continue
+		}
+
+		averageInterval := *itemStats.AverageIntervalDays
+		dueAt := itemStats.LastPurchasedAt.Add(time.Duration(averageInterval * float64(24*time.Hour)))
+		if now.Before(dueAt) {
+			continue
+		}
+
+		suggestions = append(suggestions, Suggestion{
+			Item:                catalogItem,
+			Confidence:          confidence(itemStats, now),
+			PurchaseCount:       itemStats.PurchaseCount,
+			AverageIntervalDays: averageInterval,
+			LastPurchasedAt:     itemStats.LastPurchasedAt,
+			DueAt:               dueAt,
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Confidence > suggestions[j].Confidence
+	})
+	return suggestions
+}
+
+func confidence(itemStats purchase.ItemStats, now time.Time) float64 {
+	averageInterval := *itemStats.AverageIntervalDays
+	intervals := float64(itemStats.PurchaseCount - 1)
+
+	// 1 interval gives 0.5, 3 intervals give 0.75 etc.
+	support := intervals / (intervals + 1)
+
+	regularity := 1.0
+	if itemStats.IntervalStddevDays != nil {
+		regularity = 1 / (1 + *itemStats.IntervalStddevDays/averageInterval)
+	}
+
+	timing := 1.0
+	elapsedIntervals := now.Sub(itemStats.LastPurchasedAt).Hours() / 24 / averageInterval
+	if elapsedIntervals > maxOverdueIntervals {
+		timing = maxOverdueIntervals / elapsedIntervals
+	}
+
+	return math.Round(support*regularity*timing*100) / 100
+}
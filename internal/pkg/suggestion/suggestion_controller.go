@@ -0,0 +1,99 @@
+package suggestion
+
+import (
+	"ShoppingList-Backend/internal/pkg/controller"
+	"ShoppingList-Backend/internal/pkg/item"
+	"ShoppingList-Backend/internal/pkg/list"
+	"ShoppingList-Backend/internal/pkg/purchase"
+	"ShoppingList-Backend/internal/pkg/user"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// How far back purchases are used to estimate repurchase intervals
+	PurchaseHistoryWindow = 365 * 24 * time.Hour
+	// Suggestions are regenerated periodically by the worker, this only has to outlive the period
+	cacheTTL = 25 * time.Hour
+)
+
+type SuggestionController struct {
+	suggestionRepo *SuggestionRepository
+	purchaseRepo   *purchase.PurchaseRepository
+	itemRepo       *item.ItemRepository
+	listRepo       *list.ListRepository
+}
+
+func NewSuggestionController(suggestionRepo *SuggestionRepository, purchaseRepo *purchase.PurchaseRepository, itemRepo *item.ItemRepository, listRepo *list.ListRepository) *SuggestionController {
+	return &SuggestionController{
+		suggestionRepo: suggestionRepo,
+		purchaseRepo:   purchaseRepo,
+		itemRepo:       itemRepo,
+		listRepo:       listRepo,
+	}
+}
+
+// GenerateSuggestions generates the suggestions for the household and caches them
+func (c *SuggestionController) GenerateSuggestions(householdID uuid.UUID) (*CachedSuggestions, error) {
+	now := time.Now()
+	from := now.Add(-PurchaseHistoryWindow)
+	stats, err := c.purchaseRepo.GetItemStats(householdID, purchase.StatsFilter{From: &from})
+	if err != nil {
+		return nil, fmt.Errorf("could not get item stats: %w", err)
+	}
+
+	catalog, err := c.itemRepo.GetItems(householdID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get items: %w", err)
+	}
+
+	cached := &CachedSuggestions{
+		GeneratedAt: now,
+		Suggestions: Generate(stats, catalog, now),
+	}
+	if err := c.suggestionRepo.SetSuggestions(householdID, cached, cacheTTL); err != nil {
+		return nil, fmt.Errorf("could not cache suggestions: %w", err)
+	}
+
+	return cached, nil
+}
+
+func (c *SuggestionController) GetListSuggestions(user *user.AppUser, listID uuid.UUID) ([]Suggestion, *controller.ControllerError) {
+	if user == nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("nil user"))
+	}
+
+	foundList, err := c.listRepo.GetList(listID, user)
+	if err != nil {
+		return nil, controller.CError(http.StatusNotFound, fmt.Errorf("list with ID %v not found: %w", listID, err))
+	}
+
+	cached, err := c.suggestionRepo.GetSuggestions(user.HouseholdID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get suggestions: %w", err))
+	}
+	if cached == nil {
+		// The worker has not gotten around to this household yet
+		cached, err = c.GenerateSuggestions(user.HouseholdID)
+		if err != nil {
+			return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not generate suggestions: %w", err))
+		}
+	}
+
+	itemsOnList := make(map[uuid.UUID]bool, len(foundList.Items))
+	for _, listItem := range foundList.Items {
+		itemsOnList[listItem.ItemID] = true
+	}
+
+	suggestions := []Suggestion{}
+	for _, suggestion := range cached.Suggestions {
+		if !itemsOnList[suggestion.Item.ID] {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+
+	return suggestions, nil
+}
@@ -0,0 +1,21 @@
+package suggestion
+
+import (
+	"ShoppingList-Backend/internal/pkg/item"
+	"time"
+)
+
+type Suggestion struct {
+	Item item.Item `json:"item"`
+	// Confidence is between 0 and 1
+	Confidence          float64   `json:"confidence"`
+	PurchaseCount       int       `json:"purchaseCount"`
+	AverageIntervalDays float64   `json:"averageIntervalDays"`
+	LastPurchasedAt     time.Time `json:"lastPurchasedAt"`
+	DueAt               time.Time `json:"dueAt"`
+}
+
+type CachedSuggestions struct {
+	GeneratedAt time.Time    `json:"generatedAt"`
+	Suggestions []Suggestion `json:"suggestions"`
+}
@@ -0,0 +1,53 @@
+package suggestion
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+)
+
+// SuggestionRepository caches the generated suggestions per household in redis
+type SuggestionRepository struct {
+	Redis  *redis.Pool
+	Prefix string
+}
+
+func (q *SuggestionRepository) key(householdID uuid.UUID) string {
+	return fmt.Sprintf("%v.suggestions.%v", q.Prefix, householdID)
+}
+
+// GetSuggestions returns the cached suggestions of the household, or nil if none are cached
+func (q *SuggestionRepository) GetSuggestions(householdID uuid.UUID) (*CachedSuggestions, error) {
+	conn := q.Redis.Get()
+	defer conn.Close()
+
+	data, err := redis.Bytes(conn.Do("GET", q.key(householdID)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	cached := &CachedSuggestions{}
+	if err := json.Unmarshal(data, cached); err != nil {
+		return nil, err
+	}
+	return cached, nil
+}
+
+func (q *SuggestionRepository) SetSuggestions(householdID uuid.UUID, cached *CachedSuggestions, ttl time.Duration) error {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+
+	conn := q.Redis.Get()
+	defer conn.Close()
+
+	_, err = conn.Do("SET", q.key(householdID), data, "EX", int(ttl.Seconds()))
+	return err
+}
@@ -0,0 +1,59 @@
+package ticket
+
+import (
+	"ShoppingList-Backend/internal/pkg/controller"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// How long a ticket can be used after it was created
+const ticketTTL = 30 * time.Second
+
+type TicketController struct {
+	ticketRepo *TicketRepository
+}
+
+func NewTicketController(ticketRepo *TicketRepository) *TicketController {
+	return &TicketController{
+		ticketRepo: ticketRepo,
+	}
+}
+
+func hashTicket(ticket string) string {
+	hash := sha256.Sum256([]byte(ticket))
+	return hex.EncodeToString(hash[:])
+}
+
+func (c *TicketController) CreateTicket(holder Holder) (*Ticket, *controller.ControllerError) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not generate ticket: %w", err))
+	}
+	ticket := base64.RawURLEncoding.EncodeToString(randomBytes)
+
+	expiresAt := time.Now().Add(ticketTTL)
+	if err := c.ticketRepo.SaveTicket(hashTicket(ticket), holder, ticketTTL); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not save ticket: %w", err))
+	}
+	return &Ticket{
+		Ticket:    ticket,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// RedeemTicket returns who the ticket was created for, and makes sure it cannot be used again
+func (c *TicketController) RedeemTicket(ticket string) (*Holder, *controller.ControllerError) {
+	holder, err := c.ticketRepo.TakeTicket(hashTicket(ticket))
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get ticket: %w", err))
+	}
+	if holder == nil {
+		return nil, controller.CError(http.StatusUnauthorized, fmt.Errorf("ticket is invalid, expired or already used"))
+	}
+	return holder, nil
+}
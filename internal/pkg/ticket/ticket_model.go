@@ -0,0 +1,18 @@
+package ticket
+
+import "time"
+
+// Ticket lets a connection that cannot send an Authorization header, e.g. a socket.io connection from a browser,
+// authenticate once with a query parameter. It can only be used once, shortly after it was created
+type Ticket struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Holder is who a ticket was created for, with the grants of the credentials it was created with
+type Holder struct {
+	UserID              string   `json:"userId"`
+	Scopes              []string `json:"scopes"`
+	Roles               []string `json:"roles"`
+	PersonalAccessToken bool     `json:"personalAccessToken"`
+}
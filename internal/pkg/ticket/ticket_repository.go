@@ -0,0 +1,60 @@
+package ticket
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// TicketRepository stores tickets in redis, keyed by their hash, until they are used or expire
+type TicketRepository struct {
+	Redis  *redis.Pool
+	Prefix string
+}
+
+func (q *TicketRepository) key(ticketHash string) string {
+	return fmt.Sprintf("%v.tickets.%v", q.Prefix, ticketHash)
+}
+
+func (q *TicketRepository) SaveTicket(ticketHash string, holder Holder, ttl time.Duration) error {
+	data, err := json.Marshal(holder)
+	if err != nil {
+		return err
+	}
+
+	conn := q.Redis.Get()
+	defer conn.Close()
+
+	_, err = conn.Do("SET", q.key(ticketHash), data, "EX", int(ttl.Seconds()), "NX")
+	return err
+}
+
+// TakeTicket returns the holder of the ticket and deletes it, so it cannot be used again. Returns nil if there is no such ticket
+func (q *TicketRepository) TakeTicket(ticketHash string) (*Holder, error) {
+	conn := q.Redis.Get()
+	defer conn.Close()
+
+	conn.Send("MULTI")
+	conn.Send("GET", q.key(ticketHash))
+	conn.Send("DEL", q.key(ticketHash))
+	replies, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := redis.Bytes(replies[0], nil)
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	holder := &Holder{}
+	if err := json.Unmarshal(data, holder); err != nil {
+		return nil, err
+	}
+	return holder, nil
+}
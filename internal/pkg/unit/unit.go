@@ -0,0 +1,59 @@
+package unit
+
+import "strings"
+
+type conversion struct {
+	baseUnit string
+	factor   float64
+}
+
+// Units that can be converted to a common base unit. Other units are only compatible with themselves
+var conversions = map[string]conversion{
+	"mg": {"g", 0.001},
+	"g":  {"g", 1},
+	"kg": {"g", 1000},
+	"ml": {"ml", 1},
+	"cl": {"ml", 10},
+	"dl": {"ml", 100},
+	"l":  {"ml", 1000},
+}
+
+// Normalize lower-cases and trims the unit
+func Normalize(unit string) string {
+	return strings.ToLower(strings.TrimSpace(unit))
+}
+
+// ToBase converts the quantity to the base unit of the unit, e.g. 1.5 kg to 1500 g.
+// Units without conversions are returned normalized and unchanged.
+func ToBase(quantity float64, unit string) (float64, string) {
+	unit = Normalize(unit)
+	if c, ok := conversions[unit]; ok {
+		return quantity * c.factor, c.baseUnit
+	}
+	return quantity, unit
+}
+
+// Convert converts the quantity from one unit to another. ok is false if the units are not compatible
+func Convert(quantity float64, from string, to string) (converted float64, ok bool) {
+	baseQuantity, fromBase := ToBase(quantity, from)
+	_, toBase := ToBase(1, to)
+	if fromBase != toBase {
+		return 0, false
+	}
+	if c, found := conversions[Normalize(to)]; found {
+		return baseQuantity / c.factor, true
+	}
+	return baseQuantity, true
+}
+
+// Humanize converts a quantity in a base unit to the largest unit that keeps it at or above 1, e.g. 1500 g to 1.5 kg
+func Humanize(quantity float64, unit string) (float64, string) {
+	quantity, unit = ToBase(quantity, unit)
+	switch {
+	case unit == "g" && quantity >= 1000:
+		return quantity / 1000, "kg"
+	case unit == "ml" && quantity >= 1000:
+		return quantity / 1000, "l"
+	}
+	return quantity, unit
+}
@@ -1,5 +1,9 @@
 package user
 
+import "github.com/google/uuid"
+
 type AppUser struct {
 	ID string `json:"id"`
+	// HouseholdID is the household the request acts on
+	HouseholdID uuid.UUID `json:"householdId"`
 }
@@ -0,0 +1,97 @@
+package user
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// PreferencesVersion is the current version of the preferences document.
+// Stored documents of older versions are upgraded when they are read
+const PreferencesVersion = 1
+
+const (
+	// SortOrderRecent sorts list items by when they were last changed, newest first
+	SortOrderRecent = "recent"
+	// SortOrderName sorts list items by the name of the item
+	SortOrderName = "name"
+	// SortOrderAdded sorts list items by when they were added to the list, oldest first
+	SortOrderAdded = "added"
+
+	UnitSystemMetric   = "metric"
+	UnitSystemImperial = "imperial"
+)
+
+type Preferences struct {
+	Version int `json:"version"`
+	// Order of the items on lists: recent, name or added
+	SortOrder string `json:"sortOrder" validate:"oneof=recent name added"`
+	// Clear the crossed items of a list when the last item on it is crossed
+	AutoClearCrossed bool `json:"autoClearCrossed"`
+	// Prices from this store are preferred when estimating the cost of lists
+	PreferredStore string `json:"preferredStore" validate:"max=255"`
+	// metric or imperial
+	UnitSystem    string                  `json:"unitSystem" validate:"oneof=metric imperial"`
+	Notifications NotificationPreferences `json:"notifications"`
+}
+
+// NotificationPreferences tells the clients which events to notify the user about
+type NotificationPreferences struct {
+	ListChanges    bool `json:"listChanges"`
+	BudgetExceeded bool `json:"budgetExceeded"`
+	RecurringItems bool `json:"recurringItems"`
+}
+
+func DefaultPreferences() Preferences {
+	return Preferences{
+		Version:          PreferencesVersion,
+		SortOrder:        SortOrderRecent,
+		AutoClearCrossed: false,
+		PreferredStore:   "",
+		UnitSystem:       UnitSystemMetric,
+		Notifications: NotificationPreferences{
+			ListChanges:    true,
+			BudgetExceeded: true,
+			RecurringItems: true,
+		},
+	}
+}
+
+// Scan reads a stored preferences document. Settings that are missing from it, because it was stored by an
+// older version, get their default values. Documents that cannot be read are replaced by the defaults
+func (p *Preferences) Scan(src interface{}) error {
+	preferences := DefaultPreferences()
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+	case []byte:
+		data = src
+	case string:
+		data = []byte(src)
+	default:
+		return fmt.Errorf("cannot scan %T into preferences", src)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &preferences); err != nil {
+			preferences = DefaultPreferences()
+		}
+	}
+	preferences.Version = PreferencesVersion
+	*p = preferences
+	return nil
+}
+
+func (p *Preferences) Validate() error {
+	if p.Version > PreferencesVersion {
+		return fmt.Errorf("preferences version %v is not supported, the latest version is %v", p.Version, PreferencesVersion)
+	}
+	return nil
+}
+
+func (p Preferences) Value() (driver.Value, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
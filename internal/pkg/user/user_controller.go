@@ -0,0 +1,120 @@
+package user
+
+import (
+	"ShoppingList-Backend/internal/pkg/controller"
+	"ShoppingList-Backend/pkg/validation"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type UserController struct {
+	userRepo *UserRepository
+	validate *validator.Validate
+	// synced holds the claims each user was last synced with, so the database is only written when they change
+	synced sync.Map
+}
+
+func NewUserController(userRepo *UserRepository) *UserController {
+	return &UserController{
+		userRepo: userRepo,
+		validate: validation.NewValidator(),
+	}
+}
+
+func (c *UserController) validatePreferences(preferences *Preferences) error {
+	if err := preferences.Validate(); err != nil {
+		return err
+	}
+	if err := c.validate.Struct(preferences); err != nil {
+		fields := validation.ValidatorErrors(err)
+		messages := make([]string, 0, len(fields))
+		for field, message := range fields {
+			messages = append(messages, fmt.Sprintf("%v %v", field, message))
+		}
+		sort.Strings(messages)
+		return fmt.Errorf("invalid preferences: %v", strings.Join(messages, ", "))
+	}
+	return nil
+}
+
+// SyncUser creates or updates the user from the claims of their JWT
+func (c *UserController) SyncUser(userID string, claims Claims) *controller.ControllerError {
+	if syncedClaims, ok := c.synced.Load(userID); ok && syncedClaims.(Claims) == claims {
+		return nil
+	}
+	if err := c.userRepo.SyncUser(userID, claims); err != nil {
+		return controller.CError(http.StatusInternalServerError, fmt.Errorf("could not sync user: %w", err))
+	}
+	c.synced.Store(userID, claims)
+	return nil
+}
+
+func (c *UserController) GetUser(appUser *AppUser) (*User, *controller.ControllerError) {
+	foundUser, err := c.userRepo.GetUser(appUser.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, controller.CError(http.StatusNotFound, fmt.Errorf("user with ID %v not found", appUser.ID))
+		}
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get user: %w", err))
+	}
+	return &foundUser, nil
+}
+
+func (c *UserController) UpdateUser(appUser *AppUser, updateUser *UpdateUser) (*User, *controller.ControllerError) {
+	if err := updateUser.Validate(); err != nil {
+		return nil, controller.CError(http.StatusBadRequest, err)
+	}
+
+	foundUser, cErr := c.GetUser(appUser)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	if updateUser.DisplayName != nil {
+		foundUser.DisplayName = nil
+		if displayName := strings.TrimSpace(*updateUser.DisplayName); displayName != "" {
+			foundUser.DisplayName = &displayName
+		}
+	}
+	if updateUser.Locale != nil {
+		foundUser.Locale = nil
+		if *updateUser.Locale != "" {
+			foundUser.Locale = updateUser.Locale
+		}
+	}
+
+	if err := c.userRepo.UpdateUser(foundUser); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not update user: %w", err))
+	}
+
+	return c.GetUser(appUser)
+}
+
+func (c *UserController) GetPreferences(appUser *AppUser) (*Preferences, *controller.ControllerError) {
+	preferences, err := c.userRepo.GetPreferences(appUser.ID)
+	if err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not get preferences: %w", err))
+	}
+	return &preferences, nil
+}
+
+// SetPreferences replaces the preferences of the user
+func (c *UserController) SetPreferences(appUser *AppUser, preferences *Preferences) (*Preferences, *controller.ControllerError) {
+	if err := c.validatePreferences(preferences); err != nil {
+		return nil, controller.CError(http.StatusBadRequest, err)
+	}
+	preferences.Version = PreferencesVersion
+
+	if err := c.userRepo.SetPreferences(appUser.ID, *preferences); err != nil {
+		return nil, controller.CError(http.StatusInternalServerError, fmt.Errorf("could not set preferences: %w", err))
+	}
+
+	return c.GetPreferences(appUser)
+}
@@ -0,0 +1,67 @@
+package user
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var localeRegexp = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+type User struct {
+	ID        string     `db:"id" json:"id"`
+	CreatedAt time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt *time.Time `db:"updated_at" json:"updatedAt"`
+
+	// Name, Email and PreferredUsername are taken from the claims of the JWT
+	Name              string `db:"name" json:"name"`
+	Email             string `db:"email" json:"email"`
+	PreferredUsername string `db:"preferred_username" json:"preferredUsername"`
+
+	DisplayName *string     `db:"display_name" json:"displayName"`
+	Locale      *string     `db:"locale" json:"locale"`
+	Preferences Preferences `db:"preferences" json:"preferences"`
+}
+
+// Profile returns what other users, e.g. members of the same household, can see about the user
+func (u User) Profile() Profile {
+	name := u.Name
+	if u.DisplayName != nil && *u.DisplayName != "" {
+		name = *u.DisplayName
+	} else if name == "" {
+		name = u.PreferredUsername
+	}
+	return Profile{
+		ID:   u.ID,
+		Name: name,
+	}
+}
+
+type Profile struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Claims are the profile claims of a JWT
+type Claims struct {
+	Name              string `json:"name"`
+	Email             string `json:"email"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+// UpdateUser changes the fields that are set. An empty displayName or locale removes it
+type UpdateUser struct {
+	DisplayName *string `json:"displayName"`
+	Locale      *string `json:"locale"`
+}
+
+func (u *UpdateUser) Validate() error {
+	if u.DisplayName != nil && len(strings.TrimSpace(*u.DisplayName)) > 255 {
+		return fmt.Errorf("displayName must be at most 255 characters")
+	}
+	if u.Locale != nil && *u.Locale != "" && !localeRegexp.MatchString(*u.Locale) {
+		return fmt.Errorf("locale must be a BCP 47 language tag, e.g. en-US, got %v", *u.Locale)
+	}
+	return nil
+}
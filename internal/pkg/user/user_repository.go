@@ -0,0 +1,85 @@
+package user
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type UserRepository struct {
+	DB *sqlx.DB
+}
+
+func (q *UserRepository) GetUser(id string) (User, error) {
+	user := User{}
+	query := `SELECT * FROM users WHERE id = $1`
+	err := q.DB.Get(&user, query, id)
+	return user, err
+}
+
+// GetUsers returns the users with the IDs. Users that have not made a request yet are left out
+func (q *UserRepository) GetUsers(ids []string) ([]User, error) {
+	users := []User{}
+	if len(ids) == 0 {
+		return users, nil
+	}
+	query, args, err := sqlx.In(`SELECT * FROM users WHERE id IN (?)`, ids)
+	if err != nil {
+		return users, err
+	}
+	err = q.DB.Select(&users, q.DB.Rebind(query), args...)
+	return users, err
+}
+
+// SyncUser creates the user, or updates the fields that come from the claims of the JWT
+func (q *UserRepository) SyncUser(id string, claims Claims) error {
+	query := `INSERT INTO users (id, name, email, preferred_username) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET updated_at = NOW(), name = $2, email = $3, preferred_username = $4
+		WHERE (users.name, users.email, users.preferred_username) IS DISTINCT FROM ($2, $3, $4)`
+	_, err := q.DB.Exec(query, id, claims.Name, claims.Email, claims.PreferredUsername)
+	return err
+}
+
+// DeleteUser deletes the profile of the user. The personal access tokens of the user are deleted with it
+func (q *UserRepository) DeleteUser(id string) error {
+	query := `DELETE FROM users WHERE id = $1`
+	_, err := q.DB.Exec(query, id)
+	return err
+}
+
+func (q *UserRepository) UpdateUser(user *User) error {
+	query := `UPDATE users SET updated_at = NOW(), display_name = $2, locale = $3 WHERE id = $1`
+	_, err := q.DB.Exec(query, user.ID, user.DisplayName, user.Locale)
+	return err
+}
+
+// GetPreferences returns the preferences of the user, or the defaults if the user has not been created yet
+func (q *UserRepository) GetPreferences(id string) (Preferences, error) {
+	preferences := Preferences{}
+	query := `SELECT preferences FROM users WHERE id = $1`
+	err := q.DB.Get(&preferences, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return DefaultPreferences(), nil
+	}
+	return preferences, err
+}
+
+func (q *UserRepository) SetPreferences(id string, preferences Preferences) error {
+	query := `UPDATE users SET updated_at = NOW(), preferences = $2::jsonb WHERE id = $1`
+	_, err := q.DB.Exec(query, id, preferences)
+	return err
+}
+
+// GetProfiles returns the profiles of the users with the IDs, by ID
+func (q *UserRepository) GetProfiles(ids []string) (map[string]Profile, error) {
+	profiles := make(map[string]Profile, len(ids))
+	users, err := q.GetUsers(ids)
+	if err != nil {
+		return profiles, err
+	}
+	for _, user := range users {
+		profiles[user.ID] = user.Profile()
+	}
+	return profiles, nil
+}
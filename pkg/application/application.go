@@ -1,12 +1,30 @@
 package application
 
 import (
+	"ShoppingList-Backend/internal/pkg/accesstoken"
+	"ShoppingList-Backend/internal/pkg/barcode"
+	"ShoppingList-Backend/internal/pkg/demo"
+	"ShoppingList-Backend/internal/pkg/household"
 	"ShoppingList-Backend/internal/pkg/item"
 	"ShoppingList-Backend/internal/pkg/list"
+	"ShoppingList-Backend/internal/pkg/mealplan"
+	"ShoppingList-Backend/internal/pkg/pantry"
+	"ShoppingList-Backend/internal/pkg/price"
+	"ShoppingList-Backend/internal/pkg/purchase"
+	"ShoppingList-Backend/internal/pkg/recipe"
+	"ShoppingList-Backend/internal/pkg/recurring"
+	"ShoppingList-Backend/internal/pkg/suggestion"
+	"ShoppingList-Backend/internal/pkg/ticket"
+	"ShoppingList-Backend/internal/pkg/user"
 	"ShoppingList-Backend/pkg/config"
 	"ShoppingList-Backend/pkg/db"
+	"ShoppingList-Backend/pkg/events"
+	"ShoppingList-Backend/pkg/health"
+	"ShoppingList-Backend/pkg/identity"
+	"ShoppingList-Backend/pkg/middleware"
 	"ShoppingList-Backend/pkg/server"
 	"fmt"
+	"time"
 
 	"github.com/gomodule/redigo/redis"
 	socketio "github.com/googollee/go-socket.io"
@@ -19,26 +37,26 @@ type Application struct {
 	Redis       *redis.Pool
 	Srv         *server.Server
 	SocketIo    *socketio.Server
+	Events      events.Publisher
+	// Authenticates requests to the protected routes
+	Authenticator middleware.Authenticator
+	// Manages the users of the identity provider
+	Identity identity.Provider
+	// Limits the requests of each user, or of each IP on routes that are not authenticated
+	RateLimiter *middleware.RateLimiter
+	// Checks the dependencies the API and worker need to be ready
+	Readiness *health.Checker
 }
 
 func Get(cfg *config.Config) (*Application, error) {
-	db, err := db.Get(cfg.GetDBConnStr())
+	authenticator, err := middleware.NewAuthenticator(cfg)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("could not create authenticator: %w", err)
 	}
 
-	repos := &Repositories{
-		Item: &item.ItemRepository{
-			DB: db.Client,
-		},
-		List: &list.ListRepository{
-			DB: db.Client,
-		},
-	}
-
-	controllers := &Controllers{
-		Item: item.NewItemController(repos.Item),
-		List: list.NewListController(repos.Item, repos.List),
+	db, err := db.Get(cfg.GetDBConnStr())
+	if err != nil {
+		return nil, err
 	}
 
 	redisPool := &redis.Pool{
@@ -51,21 +69,113 @@ func Get(cfg *config.Config) (*Application, error) {
 	}
 
 	socketServer := socketio.NewServer(nil)
-	_, err = socketServer.Adapter(&socketio.RedisAdapterOptions{
+	socketIoAdapterOptions := &socketio.RedisAdapterOptions{
 		Network:  "tcp",
 		Addr:     cfg.GetRedisConnStr(),
 		Prefix:   cfg.GetRedisPrefix() + ".socket.io",
 		Password: cfg.GetRedisPassword(),
-	})
+	}
+	_, err = socketServer.Adapter(socketIoAdapterOptions)
 	if err != nil {
 		return nil, fmt.Errorf("could not create redis socket io adapter: %w", err)
 	}
 
+	eventPublisher := events.NewSocketIoPublisher(socketServer)
+
+	productDatabase, err := barcode.NewFileProductDatabase(cfg.ProductDBFile)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := &Repositories{
+		Item: &item.ItemRepository{
+			DB: db.Client,
+		},
+		List: &list.ListRepository{
+			DB: db.Client,
+		},
+		Purchase: &purchase.PurchaseRepository{
+			DB: db.Client,
+		},
+		Suggestion: &suggestion.SuggestionRepository{
+			Redis:  redisPool,
+			Prefix: cfg.GetRedisPrefix(),
+		},
+		Recurring: &recurring.RecurringRepository{
+			DB: db.Client,
+		},
+		Recipe: &recipe.RecipeRepository{
+			DB: db.Client,
+		},
+		MealPlan: &mealplan.MealPlanRepository{
+			DB: db.Client,
+		},
+		Pantry: &pantry.PantryRepository{
+			DB: db.Client,
+		},
+		Price: &price.PriceRepository{
+			DB: db.Client,
+		},
+		Household: &household.HouseholdRepository{
+			DB: db.Client,
+		},
+		User: &user.UserRepository{
+			DB: db.Client,
+		},
+		AccessToken: &accesstoken.AccessTokenRepository{
+			DB: db.Client,
+		},
+		Ticket: &ticket.TicketRepository{
+			Redis:  redisPool,
+			Prefix: cfg.GetRedisPrefix(),
+		},
+	}
+
+	listController := list.NewListController(repos.Item, repos.List, eventPublisher, repos.Pantry, repos.Price, repos.User)
+	controllers := &Controllers{
+		Item:        item.NewItemController(repos.Item, repos.User, productDatabase),
+		List:        listController,
+		Purchase:    purchase.NewPurchaseController(repos.Purchase),
+		Suggestion:  suggestion.NewSuggestionController(repos.Suggestion, repos.Purchase, repos.Item, repos.List),
+		Recurring:   recurring.NewRecurringController(repos.Recurring, repos.Item, repos.List),
+		Recipe:      recipe.NewRecipeController(repos.Recipe, repos.Item, listController),
+		MealPlan:    mealplan.NewMealPlanController(repos.MealPlan, repos.Recipe, repos.Pantry, listController),
+		Pantry:      pantry.NewPantryController(repos.Pantry, repos.Item, listController),
+		Price:       price.NewPriceController(repos.Price, repos.Item, listController, repos.User),
+		Household:   household.NewHouseholdController(repos.Household, repos.User),
+		User:        user.NewUserController(repos.User),
+		AccessToken: accesstoken.NewAccessTokenController(repos.AccessToken, time.Duration(cfg.AccessTokenMaxDays)*24*time.Hour),
+		Ticket:      ticket.NewTicketController(repos.Ticket),
+	}
+
+	identityProvider := identity.NewKeycloakProvider(cfg)
+	// The users of personal access tokens are checked in Keycloak, if it is configured
+	var accessTokenUsers identity.Provider
+	if cfg.JwtKeycloakUrl != "" {
+		accessTokenUsers = identityProvider
+	}
+	controllers.Demo, err = demo.NewDemoController(identityProvider, cfg.DemoUsersGroupID, repos.Item, repos.List, repos.Recipe,
+		repos.Household, repos.User, controllers.User, controllers.Household, controllers.Item, controllers.List)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Application{
 		Cfg:         cfg,
 		Queries:     repos,
 		Redis:       redisPool,
 		Controllers: controllers,
 		SocketIo:    socketServer,
+		Events:      eventPublisher,
+
+		Authenticator: middleware.WithAccessTokens(authenticator, controllers.AccessToken, accessTokenUsers, cfg.GetJwtRequiredRoles()),
+		Identity:      identityProvider,
+		RateLimiter:   middleware.NewRateLimiter(redisPool, cfg),
+		Readiness: health.NewChecker(
+			health.PostgresCheck(db.Client, 2*time.Second),
+			health.RedisCheck(redisPool, time.Second),
+			health.SocketIoAdapterCheck(socketIoAdapterOptions, time.Second),
+			health.MigrationCheck(db.Client, 2*time.Second),
+		),
 	}, nil
 }
@@ -1,11 +1,35 @@
 package application
 
 import (
+	"ShoppingList-Backend/internal/pkg/accesstoken"
+	"ShoppingList-Backend/internal/pkg/demo"
+	"ShoppingList-Backend/internal/pkg/household"
 	"ShoppingList-Backend/internal/pkg/item"
 	"ShoppingList-Backend/internal/pkg/list"
+	"ShoppingList-Backend/internal/pkg/mealplan"
+	"ShoppingList-Backend/internal/pkg/pantry"
+	"ShoppingList-Backend/internal/pkg/price"
+	"ShoppingList-Backend/internal/pkg/purchase"
+	"ShoppingList-Backend/internal/pkg/recipe"
+	"ShoppingList-Backend/internal/pkg/recurring"
+	"ShoppingList-Backend/internal/pkg/suggestion"
+	"ShoppingList-Backend/internal/pkg/ticket"
+	"ShoppingList-Backend/internal/pkg/user"
 )
 
 type Controllers struct {
-	Item *item.ItemController
-	List *list.ListController
+	Item        *item.ItemController
+	List        *list.ListController
+	Purchase    *purchase.PurchaseController
+	Suggestion  *suggestion.SuggestionController
+	Recurring   *recurring.RecurringController
+	Recipe      *recipe.RecipeController
+	MealPlan    *mealplan.MealPlanController
+	Pantry      *pantry.PantryController
+	Price       *price.PriceController
+	Household   *household.HouseholdController
+	User        *user.UserController
+	AccessToken *accesstoken.AccessTokenController
+	Ticket      *ticket.TicketController
+	Demo        *demo.DemoController
 }
@@ -1,11 +1,33 @@
 package application
 
 import (
+	"ShoppingList-Backend/internal/pkg/accesstoken"
+	"ShoppingList-Backend/internal/pkg/household"
 	"ShoppingList-Backend/internal/pkg/item"
 	"ShoppingList-Backend/internal/pkg/list"
+	"ShoppingList-Backend/internal/pkg/mealplan"
+	"ShoppingList-Backend/internal/pkg/pantry"
+	"ShoppingList-Backend/internal/pkg/price"
+	"ShoppingList-Backend/internal/pkg/purchase"
+	"ShoppingList-Backend/internal/pkg/recipe"
+	"ShoppingList-Backend/internal/pkg/recurring"
+	"ShoppingList-Backend/internal/pkg/suggestion"
+	"ShoppingList-Backend/internal/pkg/ticket"
+	"ShoppingList-Backend/internal/pkg/user"
 )
 
 type Repositories struct {
-	Item *item.ItemRepository
-	List *list.ListRepository
+	Item        *item.ItemRepository
+	List        *list.ListRepository
+	Purchase    *purchase.PurchaseRepository
+	Suggestion  *suggestion.SuggestionRepository
+	Recurring   *recurring.RecurringRepository
+	Recipe      *recipe.RecipeRepository
+	MealPlan    *mealplan.MealPlanRepository
+	Pantry      *pantry.PantryRepository
+	Price       *price.PriceRepository
+	Household   *household.HouseholdRepository
+	User        *user.UserRepository
+	AccessToken *accesstoken.AccessTokenRepository
+	Ticket      *ticket.TicketRepository
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"go.uber.org/zap"
 )
@@ -12,12 +13,38 @@ import (
 type Config struct {
 	apiPort           string
 	workerWebUIPort   string
+	workerHealthPort  string
 	ServerReadTimeout int
 
-	JwtJwksUrl          string
+	// jwks, publickey, hmac or dev. Defaults to jwks
+	authMode string
+	// Dev mode trusts a header to be the user ID, so it must be explicitly enabled
+	AuthDevModeEnabled bool
+	// PEM encoded RSA or ECDSA public key, used in publickey mode
+	JwtPublicKeyFile string
+	// Shared secret, used in hmac mode
+	jwtHmacSecret string
+
+	JwtJwksUrl string
+	// Expected iss claim. Not checked if empty
+	JwtIssuer    string
+	jwtAudiences string
+	// Seconds of clock skew allowed when checking exp, nbf and iat
+	JwtLeeway         int
+	jwtAlgorithms     string
+	jwtRequiredScopes string
+	jwtRequiredRoles  string
+
 	JwtKeycloakUrl      string
 	JwtKeycloakUsername string
 	JwtKeycloakPassword string
+	// Realm of the users, and the realm the admin logs in to
+	jwtKeycloakRealm      string
+	jwtKeycloakAdminRealm string
+	// Keycloak group of the demo users, whose data is cleaned up daily. Not cleaned up if empty
+	DemoUsersGroupID string
+	// Personal access tokens expire after at most this many days
+	AccessTokenMaxDays int
 
 	dbHost     string
 	dbPort     string
@@ -39,6 +66,25 @@ type Config struct {
 
 	LogFormat string
 
+	// Comma separated. Origins can have one wildcard, e.g. https://*.example.org
+	corsAllowedOrigins   string
+	corsAllowedMethods   string
+	corsAllowedHeaders   string
+	CorsAllowCredentials bool
+	// Seconds browsers can cache preflight responses
+	CorsMaxAge int
+
+	// Comma separated group=rate:burst, e.g. default=600/m:100,demo=10/h:2
+	rateLimits string
+	// Use the first X-Forwarded-For address as the client IP. Only enable behind a proxy that sets it
+	RateLimitTrustForwardedFor bool
+
+	// development or production
+	appEnv string
+
+	// JSON file with products to look up barcodes in
+	ProductDBFile string
+
 	Migrate          string
 	MigrateOnStartup bool
 }
@@ -48,6 +94,7 @@ func New() *Config {
 
 	flag.StringVar(&conf.apiPort, "apiport", os.Getenv("API_PORT"), "Which port for the API server to listen on")
 	flag.StringVar(&conf.workerWebUIPort, "workerwebuiport", os.Getenv("WORKER_WEBUI_PORT"), "Which port for the worker web UI server to listen on")
+	flag.StringVar(&conf.workerHealthPort, "workerhealthport", os.Getenv("WORKER_HEALTH_PORT"), "Which port for the worker health checks to listen on. Defaults to 8081")
 
 	serverReadTimeout, err := strconv.Atoi(os.Getenv("SERVER_READ_TIMEOUT"))
 	if err != nil {
@@ -56,10 +103,37 @@ func New() *Config {
 	}
 	flag.IntVar(&conf.ServerReadTimeout, "serverreadtimeout", serverReadTimeout, "Server read timeout")
 
+	flag.StringVar(&conf.authMode, "authmode", os.Getenv("AUTH_MODE"), "How requests are authenticated: jwks, publickey, hmac or dev")
+	authDevModeEnabled, err := strconv.ParseBool(os.Getenv("AUTH_DEV_MODE_ENABLED"))
+	if err != nil {
+		authDevModeEnabled = false
+	}
+	flag.BoolVar(&conf.AuthDevModeEnabled, "authdevmodeenabled", authDevModeEnabled, "Allow the dev auth mode, which trusts the X-Dev-User-ID header")
+	flag.StringVar(&conf.JwtPublicKeyFile, "jwtpublickeyfile", os.Getenv("JWT_PUBLIC_KEY_FILE"), "PEM encoded public key to verify JWTs with in publickey mode")
+	flag.StringVar(&conf.jwtHmacSecret, "jwthmacsecret", os.Getenv("JWT_HMAC_SECRET"), "Secret to verify JWTs with in hmac mode")
 	flag.StringVar(&conf.JwtJwksUrl, "jwtjwksurl", os.Getenv("JWT_JWKS_URL"), "JWT JWKS URL")
+	flag.StringVar(&conf.JwtIssuer, "jwtissuer", os.Getenv("JWT_ISSUER"), "Expected JWT issuer")
+	flag.StringVar(&conf.jwtAudiences, "jwtaudiences", os.Getenv("JWT_AUDIENCES"), "Comma separated JWT audiences, of which a token must have at least one")
+	jwtLeeway, err := strconv.Atoi(os.Getenv("JWT_LEEWAY_SECONDS"))
+	if err != nil {
+		zap.S().Errorf("Could not read JWT_LEEWAY_SECONDS: %v", err)
+		jwtLeeway = 60
+	}
+	flag.IntVar(&conf.JwtLeeway, "jwtleeway", jwtLeeway, "Seconds of clock skew allowed when validating JWTs")
+	flag.StringVar(&conf.jwtAlgorithms, "jwtalgorithms", os.Getenv("JWT_ALGORITHMS"), "Comma separated JWT signing algorithms to accept. Defaults to RS256, ES256 for ECDSA public keys and HS256 in hmac mode")
+	flag.StringVar(&conf.jwtRequiredScopes, "jwtrequiredscopes", os.Getenv("JWT_REQUIRED_SCOPES"), "Comma separated scopes a JWT must have to use the API")
+	flag.StringVar(&conf.jwtRequiredRoles, "jwtrequiredroles", os.Getenv("JWT_REQUIRED_ROLES"), "Comma separated roles a JWT must have to use the API. Client roles are given as client:role")
 	flag.StringVar(&conf.JwtKeycloakUrl, "jwtkeycloakurl", os.Getenv("JWT_KEYCLOAK_URL"), "JWT Keycloak URL")
 	flag.StringVar(&conf.JwtKeycloakUsername, "jwtkeycloakusername", os.Getenv("JWT_KEYCLOAK_USERNAME"), "Keycloak username")
 	flag.StringVar(&conf.JwtKeycloakPassword, "jwtkeycloakpassword", os.Getenv("JWT_KEYCLOAK_PASSWORD"), "Keycloak password")
+	flag.StringVar(&conf.jwtKeycloakRealm, "jwtkeycloakrealm", os.Getenv("JWT_KEYCLOAK_REALM"), "Keycloak realm of the users. Defaults to shoppinglist")
+	flag.StringVar(&conf.jwtKeycloakAdminRealm, "jwtkeycloakadminrealm", os.Getenv("JWT_KEYCLOAK_ADMIN_REALM"), "Keycloak realm to log in to as admin. Defaults to master")
+	flag.StringVar(&conf.DemoUsersGroupID, "demousersgroupid", os.Getenv("DEMO_USERS_GROUP_ID"), "Keycloak group ID of the demo users")
+	accessTokenMaxDays, err := strconv.Atoi(os.Getenv("ACCESS_TOKEN_MAX_DAYS"))
+	if err != nil || accessTokenMaxDays <= 0 {
+		accessTokenMaxDays = 365
+	}
+	flag.IntVar(&conf.AccessTokenMaxDays, "accesstokenmaxdays", accessTokenMaxDays, "Days personal access tokens can be valid for at most")
 
 	flag.StringVar(&conf.dbHost, "dbhost", os.Getenv("DB_HOST"), "Database host")
 	flag.StringVar(&conf.dbPort, "dbport", os.Getenv("DB_PORT"), "Database port")
@@ -79,8 +153,33 @@ func New() *Config {
 	flag.StringVar(&conf.rabbitmqPassword, "rabbitmqpassword", os.Getenv("RABBITMQ_PASSWORD"), "RabbitMQ password")
 	flag.StringVar(&conf.rabbitmqVHost, "rabbitmqvhost", os.Getenv("RABBITMQ_VHOST"), "RabbitMQ vHost")
 
+	flag.StringVar(&conf.corsAllowedOrigins, "corsallowedorigins", os.Getenv("CORS_ALLOWED_ORIGINS"), "Comma separated origins allowed to call the API, e.g. https://*.example.org. Defaults to *")
+	flag.StringVar(&conf.corsAllowedMethods, "corsallowedmethods", os.Getenv("CORS_ALLOWED_METHODS"), "Comma separated methods allowed in CORS requests")
+	flag.StringVar(&conf.corsAllowedHeaders, "corsallowedheaders", os.Getenv("CORS_ALLOWED_HEADERS"), "Comma separated headers allowed in CORS requests")
+	corsAllowCredentials, err := strconv.ParseBool(os.Getenv("CORS_ALLOW_CREDENTIALS"))
+	if err != nil {
+		corsAllowCredentials = false
+	}
+	flag.BoolVar(&conf.CorsAllowCredentials, "corsallowcredentials", corsAllowCredentials, "Allow CORS requests with credentials")
+	corsMaxAge, err := strconv.Atoi(os.Getenv("CORS_MAX_AGE_SECONDS"))
+	if err != nil {
+		corsMaxAge = 600
+	}
+	flag.IntVar(&conf.CorsMaxAge, "corsmaxage", corsMaxAge, "Seconds browsers can cache CORS preflight responses")
+
+	flag.StringVar(&conf.rateLimits, "ratelimits", os.Getenv("RATE_LIMITS"), "Comma separated rate limits per route group, as group=rate:burst, e.g. default=600/m:100,demo=10/h:2")
+	rateLimitTrustForwardedFor, err := strconv.ParseBool(os.Getenv("RATE_LIMIT_TRUST_FORWARDED_FOR"))
+	if err != nil {
+		rateLimitTrustForwardedFor = false
+	}
+	flag.BoolVar(&conf.RateLimitTrustForwardedFor, "ratelimittrustforwardedfor", rateLimitTrustForwardedFor, "Rate limit unauthenticated requests by the first X-Forwarded-For address")
+
 	flag.StringVar(&conf.LogFormat, "logformat", os.Getenv("LOG_FORMAT"), "Log format (json or console)")
 
+	flag.StringVar(&conf.appEnv, "appenv", os.Getenv("APP_ENV"), "Environment (development or production)")
+
+	flag.StringVar(&conf.ProductDBFile, "productdbfile", os.Getenv("PRODUCT_DB_FILE"), "JSON file with products to look up barcodes in")
+
 	flag.StringVar(&conf.Migrate, "migrate", "up", "Specify if we should migrate DB 'up' or 'down'")
 	migrateOnStartup, err := strconv.ParseBool(os.Getenv("DB_MIGRATE_ON_STARTUP"))
 	if err != nil {
@@ -140,3 +239,162 @@ func (c *Config) GetServerUrl() string {
 func (c *Config) GetWorkerPort() string {
 	return fmt.Sprintf(":%v", c.workerWebUIPort)
 }
+
+func (c *Config) GetWorkerHealthPort() string {
+	if c.workerHealthPort == "" {
+		return ":8081"
+	}
+	return fmt.Sprintf(":%v", c.workerHealthPort)
+}
+
+// splitList splits a comma separated list, leaving out empty values
+func splitList(list string) []string {
+	values := []string{}
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func (c *Config) GetJwtAudiences() []string {
+	return splitList(c.jwtAudiences)
+}
+
+// GetJwtAlgorithms returns the configured algorithms. Empty if the default of the auth mode should be used
+func (c *Config) GetJwtAlgorithms() []string {
+	return splitList(c.jwtAlgorithms)
+}
+
+func (c *Config) GetJwtRequiredScopes() []string {
+	return splitList(c.jwtRequiredScopes)
+}
+
+func (c *Config) GetJwtRequiredRoles() []string {
+	return splitList(c.jwtRequiredRoles)
+}
+
+func (c *Config) GetKeycloakRealm() string {
+	if c.jwtKeycloakRealm == "" {
+		return "shoppinglist"
+	}
+	return c.jwtKeycloakRealm
+}
+
+func (c *Config) GetKeycloakAdminRealm() string {
+	if c.jwtKeycloakAdminRealm == "" {
+		return "master"
+	}
+	return c.jwtKeycloakAdminRealm
+}
+
+func (c *Config) GetAuthMode() string {
+	if c.authMode == "" {
+		return "jwks"
+	}
+	return strings.ToLower(strings.TrimSpace(c.authMode))
+}
+
+func (c *Config) GetJwtHmacSecret() []byte {
+	return []byte(c.jwtHmacSecret)
+}
+
+func (c *Config) IsProduction() bool {
+	return strings.EqualFold(strings.TrimSpace(c.appEnv), "production")
+}
+
+func (c *Config) GetCorsAllowedOrigins() []string {
+	origins := splitList(c.corsAllowedOrigins)
+	if len(origins) == 0 {
+		return []string{"*"}
+	}
+	return origins
+}
+
+func (c *Config) GetCorsAllowedMethods() []string {
+	methods := splitList(c.corsAllowedMethods)
+	if len(methods) == 0 {
+		return []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"}
+	}
+	return methods
+}
+
+func (c *Config) GetCorsAllowedHeaders() []string {
+	headers := splitList(c.corsAllowedHeaders)
+	if len(headers) == 0 {
+		headers = []string{"Authorization", "Content-Type", "X-Household-ID", "X-Request-ID", "If-None-Match", "If-Match"}
+		// Browsers must be able to send the header dev mode authenticates with
+		if c.GetAuthMode() == "dev" {
+			headers = append(headers, "X-Dev-User-ID")
+		}
+	}
+	return headers
+}
+
+// RateLimit is a token bucket that holds Burst tokens, and is refilled with Rate tokens per second.
+// A Rate of 0 means there is no limit
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// Used for groups that are not configured
+var defaultRateLimits = map[string]RateLimit{
+	"default": {Rate: 10, Burst: 100},
+	"demo":    {Rate: 10.0 / 3600, Burst: 2},
+}
+
+// parseRateLimit parses rate:burst, where rate is a number of requests per s, m or h, e.g. 600/m:100, or off
+func parseRateLimit(value string) (RateLimit, error) {
+	if value == "off" {
+		return RateLimit{}, nil
+	}
+	rate, burst, found := strings.Cut(value, ":")
+	if !found {
+		return RateLimit{}, fmt.Errorf("rate limit %v must be rate:burst", value)
+	}
+	count, per, found := strings.Cut(rate, "/")
+	if !found {
+		return RateLimit{}, fmt.Errorf("rate %v must be count/unit", rate)
+	}
+	requests, err := strconv.ParseFloat(count, 64)
+	if err != nil || requests <= 0 {
+		return RateLimit{}, fmt.Errorf("rate %v must have a positive count", rate)
+	}
+	seconds := map[string]float64{"s": 1, "m": 60, "h": 3600}[per]
+	if seconds == 0 {
+		return RateLimit{}, fmt.Errorf("rate %v must be per s, m or h", rate)
+	}
+	burstSize, err := strconv.Atoi(burst)
+	if err != nil || burstSize < 1 {
+		return RateLimit{}, fmt.Errorf("burst %v must be a positive integer", burst)
+	}
+	return RateLimit{Rate: requests / seconds, Burst: burstSize}, nil
+}
+
+// GetRateLimit returns the rate limit of the route group, falling back to the default group
+func (c *Config) GetRateLimit(group string) RateLimit {
+	limits := map[string]RateLimit{}
+	for name, limit := range defaultRateLimits {
+		limits[name] = limit
+	}
+	for _, entry := range splitList(c.rateLimits) {
+		name, value, found := strings.Cut(entry, "=")
+		if !found {
+			zap.S().Errorf("Could not read RATE_LIMITS entry %v: must be group=rate:burst", entry)
+			continue
+		}
+		limit, err := parseRateLimit(strings.TrimSpace(value))
+		if err != nil {
+			zap.S().Errorf("Could not read RATE_LIMITS entry %v: %v", entry, err)
+			continue
+		}
+		limits[strings.TrimSpace(name)] = limit
+	}
+
+	if limit, ok := limits[group]; ok {
+		return limit
+	}
+	return limits["default"]
+}
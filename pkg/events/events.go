@@ -0,0 +1,53 @@
+package events
+
+import (
+	"github.com/google/uuid"
+	socketio "github.com/googollee/go-socket.io"
+	"go.uber.org/zap"
+)
+
+const namespace = "/"
+
+type Event struct {
+	EventType string
+	EventData interface{}
+}
+
+// Publisher sends events to the clients in the rooms
+type Publisher interface {
+	Publish(event Event, rooms ...string)
+}
+
+// UserRoom is the socket.io room that all connections of a user join
+func UserRoom(userID string) string {
+	return "user." + userID
+}
+
+// HouseholdRoom is the socket.io room that all connections of the members of a household join
+func HouseholdRoom(householdID uuid.UUID) string {
+	return "household." + householdID.String()
+}
+
+// SocketIoPublisher emits events to socket.io rooms.
+// With the redis adapter, the events reach clients connected to any API replica, also when published from the worker.
+type SocketIoPublisher struct {
+	server *socketio.Server
+}
+
+func NewSocketIoPublisher(server *socketio.Server) *SocketIoPublisher {
+	// Broadcasting requires the namespace to be registered, which the worker would otherwise never do
+	server.OnError(namespace, func(c socketio.Conn, err error) {
+		zap.S().Errorw("socket.io error", "id", c.ID(), "error", err)
+	})
+	return &SocketIoPublisher{
+		server: server,
+	}
+}
+
+func (p *SocketIoPublisher) Publish(event Event, rooms ...string) {
+	for _, room := range rooms {
+		if !p.server.BroadcastToRoom(namespace, room, event.EventType, event.EventData) {
+			zap.S().Errorw("Could not publish event", "eventType", event.EventType, "room", room)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package health
+
+import (
+	"ShoppingList-Backend/internal/pkg/migration"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	socketio "github.com/googollee/go-socket.io"
+	"github.com/jmoiron/sqlx"
+)
+
+// remaining is the time left until the deadline of the context, for clients that take timeouts instead of contexts
+func remaining(ctx context.Context) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	return time.Until(deadline)
+}
+
+func ping(ctx context.Context, conn redis.Conn) error {
+	reply, err := redis.String(redis.DoWithTimeout(conn, remaining(ctx), "PING"))
+	if err != nil {
+		return err
+	}
+	if reply != "PONG" {
+		return fmt.Errorf("unexpected reply to PING: %v", reply)
+	}
+	return nil
+}
+
+func PostgresCheck(db *sqlx.DB, timeout time.Duration) Check {
+	return Check{
+		Name:    "postgres",
+		Timeout: timeout,
+		Check: func(ctx context.Context) error {
+			return db.PingContext(ctx)
+		},
+	}
+}
+
+func RedisCheck(pool *redis.Pool, timeout time.Duration) Check {
+	return Check{
+		Name:    "redis",
+		Timeout: timeout,
+		Check: func(ctx context.Context) error {
+			conn, err := pool.GetContext(ctx)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+			return ping(ctx, conn)
+		},
+	}
+}
+
+// SocketIoAdapterCheck checks the redis the socket.io adapter uses to reach clients connected to other replicas.
+// The adapter does not expose its connections, so a new connection is made with the same options
+func SocketIoAdapterCheck(opts *socketio.RedisAdapterOptions, timeout time.Duration) Check {
+	return Check{
+		Name:    "socketio-adapter",
+		Timeout: timeout,
+		Check: func(ctx context.Context) error {
+			conn, err := redis.DialContext(ctx, opts.Network, opts.Addr, redis.DialPassword(opts.Password))
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+			return ping(ctx, conn)
+		},
+	}
+}
+
+// MigrationCheck checks that the database has been migrated to the newest migration the service has.
+// A newer database is accepted, since it is migrated before the replicas are updated
+func MigrationCheck(db *sqlx.DB, timeout time.Duration) Check {
+	return Check{
+		Name:    "migrations",
+		Timeout: timeout,
+		Check: func(ctx context.Context) error {
+			latest, err := migration.LatestVersion()
+			if err != nil {
+				return err
+			}
+			version, dirty, err := migration.DatabaseVersion(ctx, db)
+			if err != nil {
+				return err
+			}
+			if dirty {
+				return fmt.Errorf("migration %v failed and must be fixed manually", version)
+			}
+			if version < latest {
+				return fmt.Errorf("database is at migration %v, but %v is required", version, latest)
+			}
+			return nil
+		},
+	}
+}
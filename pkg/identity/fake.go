@@ -0,0 +1,140 @@
+package identity
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FakeProvider keeps users and groups in memory, for running without an identity provider
+type FakeProvider struct {
+	mu     sync.Mutex
+	users  map[string]User
+	groups map[string]map[string]bool
+	roles  map[string][]string
+}
+
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{
+		users:  map[string]User{},
+		groups: map[string]map[string]bool{},
+		roles:  map[string][]string{},
+	}
+}
+
+// AddUser adds or replaces the user, and makes them a member of the groups
+func (p *FakeProvider) AddUser(user User, groupIDs ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.addUser(user, groupIDs...)
+}
+
+func (p *FakeProvider) addUser(user User, groupIDs ...string) {
+	p.users[user.ID] = user
+	for _, groupID := range groupIDs {
+		if p.groups[groupID] == nil {
+			p.groups[groupID] = map[string]bool{}
+		}
+		p.groups[groupID][user.ID] = true
+	}
+}
+
+// GetGroupMembers returns the members of the group, ordered by ID
+func (p *FakeProvider) GetGroupMembers(ctx context.Context, groupID string) ([]User, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	users := []User{}
+	for userID := range p.groups[groupID] {
+		users = append(users, p.users[userID])
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+	return users, nil
+}
+
+func (p *FakeProvider) GetUser(ctx context.Context, userID string) (*User, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	user, ok := p.users[userID]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	return &user, nil
+}
+
+// SetRoles replaces the roles of the user. Client roles are given as client:role
+func (p *FakeProvider) SetRoles(userID string, roles ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.roles[userID] = roles
+}
+
+// GetUserRoles returns the realm roles of the user, and their roles of the clients
+func (p *FakeProvider) GetUserRoles(ctx context.Context, userID string, clientIDs []string) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.users[userID]; !ok {
+		return nil, ErrUserNotFound
+	}
+	roles := []string{}
+	for _, role := range p.roles[userID] {
+		client, _, isClientRole := strings.Cut(role, ":")
+		if !isClientRole || containsString(clientIDs, client) {
+			roles = append(roles, role)
+		}
+	}
+	return roles, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *FakeProvider) CreateUser(ctx context.Context, newUser NewUser) (*User, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, user := range p.users {
+		if user.Username == newUser.Username {
+			return nil, ErrUserExists
+		}
+	}
+
+	user := User{
+		ID:        uuid.New().String(),
+		Username:  newUser.Username,
+		Email:     newUser.Email,
+		Enabled:   true,
+		CreatedAt: time.Now(),
+	}
+	p.addUser(user, newUser.GroupIDs...)
+	return &user, nil
+}
+
+func (p *FakeProvider) DeleteUser(ctx context.Context, userID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.users[userID]; !ok {
+		return ErrUserNotFound
+	}
+	delete(p.users, userID)
+	delete(p.roles, userID)
+	for _, members := range p.groups {
+		delete(members, userID)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrUserNotFound is returned when the identity provider has no user with the ID
+	ErrUserNotFound = errors.New("user not found")
+	// ErrUserExists is returned when creating a user with a username that is taken
+	ErrUserExists = errors.New("user already exists")
+)
+
+// User is a user of the identity provider. The ID is the subject of their JWTs
+type User struct {
+	ID        string
+	Username  string
+	Email     string
+	Enabled   bool
+	CreatedAt time.Time
+}
+
+// NewUser is a user to create, with a permanent password
+type NewUser struct {
+	Username string
+	Email    string
+	Password string
+	// Groups the user is added to
+	GroupIDs []string
+}
+
+// Provider manages the users of the identity provider, e.g. Keycloak
+type Provider interface {
+	GetGroupMembers(ctx context.Context, groupID string) ([]User, error)
+	GetUser(ctx context.Context, userID string) (*User, error)
+	// GetUserRoles returns the realm roles of the user as is, and their roles of the clients as client:role
+	GetUserRoles(ctx context.Context, userID string, clientIDs []string) ([]string, error)
+	CreateUser(ctx context.Context, newUser NewUser) (*User, error)
+	DeleteUser(ctx context.Context, userID string) error
+}
@@ -0,0 +1,212 @@
+package identity
+
+import (
+	"ShoppingList-Backend/pkg/config"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Nerzal/gocloak/v8"
+)
+
+// Group members are fetched in pages of this size
+const keycloakPageSize = 100
+
+// KeycloakProvider manages users with the Keycloak admin API. It logs in as admin when it is first used,
+// and again when the token is about to expire
+type KeycloakProvider struct {
+	client     gocloak.GoCloak
+	realm      string
+	adminRealm string
+	username   string
+	password   string
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+func NewKeycloakProvider(cfg *config.Config) *KeycloakProvider {
+	return &KeycloakProvider{
+		client:     gocloak.NewClient(cfg.JwtKeycloakUrl),
+		realm:      cfg.GetKeycloakRealm(),
+		adminRealm: cfg.GetKeycloakAdminRealm(),
+		username:   cfg.JwtKeycloakUsername,
+		password:   cfg.JwtKeycloakPassword,
+	}
+}
+
+func (p *KeycloakProvider) token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.accessToken != "" && time.Now().Before(p.expiresAt) {
+		return p.accessToken, nil
+	}
+	token, err := p.client.LoginAdmin(ctx, p.username, p.password, p.adminRealm)
+	if err != nil {
+		return "", fmt.Errorf("could not log in to keycloak as admin: %w", err)
+	}
+	p.accessToken = token.AccessToken
+	// Leave some time for the request the token is used for
+	p.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - 10*time.Second)
+	return p.accessToken, nil
+}
+
+func toUser(user *gocloak.User) User {
+	foundUser := User{
+		ID:       gocloak.PString(user.ID),
+		Username: gocloak.PString(user.Username),
+		Email:    gocloak.PString(user.Email),
+		Enabled:  gocloak.PBool(user.Enabled),
+	}
+	if user.CreatedTimestamp != nil {
+		foundUser.CreatedAt = time.UnixMilli(*user.CreatedTimestamp)
+	}
+	return foundUser
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *gocloak.APIError
+	return errors.As(err, &apiErr) && apiErr.Code == status
+}
+
+func (p *KeycloakProvider) GetGroupMembers(ctx context.Context, groupID string) ([]User, error) {
+	token, err := p.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users := []User{}
+	for first := 0; ; first += keycloakPageSize {
+		members, err := p.client.GetGroupMembers(ctx, token, p.realm, groupID, gocloak.GetGroupsParams{
+			First: gocloak.IntP(first),
+			Max:   gocloak.IntP(keycloakPageSize),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not get members of group %v: %w", groupID, err)
+		}
+		for _, member := range members {
+			users = append(users, toUser(member))
+		}
+		if len(members) < keycloakPageSize {
+			return users, nil
+		}
+	}
+}
+
+func (p *KeycloakProvider) GetUser(ctx context.Context, userID string) (*User, error) {
+	token, err := p.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := p.client.GetUserByID(ctx, token, p.realm, userID)
+	if err != nil {
+		if hasStatus(err, http.StatusNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("could not get user %v: %w", userID, err)
+	}
+	foundUser := toUser(user)
+	return &foundUser, nil
+}
+
+func (p *KeycloakProvider) GetUserRoles(ctx context.Context, userID string, clientIDs []string) ([]string, error) {
+	token, err := p.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	realmRoles, err := p.client.GetCompositeRealmRolesByUserID(ctx, token, p.realm, userID)
+	if err != nil {
+		if hasStatus(err, http.StatusNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("could not get realm roles of user %v: %w", userID, err)
+	}
+	roles := []string{}
+	for _, role := range realmRoles {
+		roles = append(roles, gocloak.PString(role.Name))
+	}
+
+	for _, clientID := range clientIDs {
+		clients, err := p.client.GetClients(ctx, token, p.realm, gocloak.GetClientsParams{ClientID: gocloak.StringP(clientID)})
+		if err != nil {
+			return nil, fmt.Errorf("could not get client %v: %w", clientID, err)
+		}
+		if len(clients) == 0 {
+			continue
+		}
+		clientRoles, err := p.client.GetCompositeClientRolesByUserID(ctx, token, p.realm, gocloak.PString(clients[0].ID), userID)
+		if err != nil {
+			if hasStatus(err, http.StatusNotFound) {
+				return nil, ErrUserNotFound
+			}
+			return nil, fmt.Errorf("could not get roles of user %v for client %v: %w", userID, clientID, err)
+		}
+		for _, role := range clientRoles {
+			roles = append(roles, clientID+":"+gocloak.PString(role.Name))
+		}
+	}
+	return roles, nil
+}
+
+// CreateUser creates the user with the password, and adds them to the groups. If a step fails, the user is deleted again
+func (p *KeycloakProvider) CreateUser(ctx context.Context, newUser NewUser) (*User, error) {
+	token, err := p.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := p.client.CreateUser(ctx, token, p.realm, gocloak.User{
+		Username:      gocloak.StringP(newUser.Username),
+		Email:         gocloak.StringP(newUser.Email),
+		Enabled:       gocloak.BoolP(true),
+		EmailVerified: gocloak.BoolP(true),
+	})
+	if err != nil {
+		if hasStatus(err, http.StatusConflict) {
+			return nil, ErrUserExists
+		}
+		return nil, fmt.Errorf("could not create user %v: %w", newUser.Username, err)
+	}
+
+	setUp := func() error {
+		if err := p.client.SetPassword(ctx, token, userID, p.realm, newUser.Password, false); err != nil {
+			return fmt.Errorf("could not set password of user %v: %w", userID, err)
+		}
+		for _, groupID := range newUser.GroupIDs {
+			if err := p.client.AddUserToGroup(ctx, token, p.realm, userID, groupID); err != nil {
+				return fmt.Errorf("could not add user %v to group %v: %w", userID, groupID, err)
+			}
+		}
+		return nil
+	}
+	if err := setUp(); err != nil {
+		if deleteErr := p.client.DeleteUser(ctx, token, p.realm, userID); deleteErr != nil {
+			return nil, fmt.Errorf("%w, and could not delete the user again: %v", err, deleteErr)
+		}
+		return nil, err
+	}
+
+	return p.GetUser(ctx, userID)
+}
+
+func (p *KeycloakProvider) DeleteUser(ctx context.Context, userID string) error {
+	token, err := p.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := p.client.DeleteUser(ctx, token, p.realm, userID); err != nil {
+		if hasStatus(err, http.StatusNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("could not delete user %v: %w", userID, err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"ShoppingList-Backend/internal/pkg/accesstoken"
+	"ShoppingList-Backend/internal/pkg/controller"
+	"ShoppingList-Backend/pkg/identity"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type AccessTokenVerifier interface {
+	VerifyAccessToken(token string) (*accesstoken.AccessToken, *controller.ControllerError)
+}
+
+// The user of a personal access token is looked up in the identity provider at most this often
+const accessTokenUserCacheTTL = time.Minute
+
+// WithAccessTokens accepts personal access tokens as bearer tokens, and passes all other requests on to the authenticator.
+// Personal access tokens do not carry the user's roles like JWTs do, so the user is looked up in the identity provider,
+// and tokens stop working when the user is deleted or disabled. The roles of the user are given to the request,
+// so JWTProtected checks the required roles against them. If users is nil, tokens have no roles
+func WithAccessTokens(authenticator Authenticator, verifier AccessTokenVerifier, users identity.Provider, requiredRoles []string) Authenticator {
+	clientIDs := []string{}
+	for _, role := range requiredRoles {
+		if client, _, isClientRole := strings.Cut(role, ":"); isClientRole && !containsAny(clientIDs, []string{client}) {
+			clientIDs = append(clientIDs, client)
+		}
+	}
+	return &accessTokenAuthenticator{
+		next:      authenticator,
+		verifier:  verifier,
+		users:     users,
+		clientIDs: clientIDs,
+		cache:     map[string]cachedAccessTokenUser{},
+	}
+}
+
+type cachedAccessTokenUser struct {
+	roles []string
+	// Set if the user was not found or is disabled
+	err       error
+	expiresAt time.Time
+}
+
+type accessTokenAuthenticator struct {
+	next     Authenticator
+	verifier AccessTokenVerifier
+	users    identity.Provider
+	// Clients of the required roles, which the roles of the user are fetched for
+	clientIDs []string
+
+	mu    sync.Mutex
+	cache map[string]cachedAccessTokenUser
+}
+
+func (a *accessTokenAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	token, ok := bearerToken(r)
+	if !ok || !strings.HasPrefix(token, accesstoken.TokenPrefix) {
+		return a.next.Authenticate(r)
+	}
+
+	accessToken, cErr := a.verifier.VerifyAccessToken(token)
+	if cErr != nil {
+		if cErr.StatusCode >= http.StatusInternalServerError {
+			return nil, fmt.Errorf("%w: %v", ErrAuthenticatorUnavailable, cErr.Err)
+		}
+		return nil, cErr.Err
+	}
+
+	roles, err := a.rolesOf(r.Context(), accessToken.UserID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Identity{
+		UserID: accessToken.UserID,
+		// The profile is kept up to date from the user's JWTs only
+		Claims: nil,
+		Grants: &Grants{
+			Scopes:              accessToken.Scopes,
+			Roles:               roles,
+			PersonalAccessToken: true,
+		},
+	}, nil
+}
+
+// rolesOf returns the roles of the user, or an error if the user no longer exists or is disabled
+func (a *accessTokenAuthenticator) rolesOf(ctx context.Context, userID string, now time.Time) ([]string, error) {
+	if a.users == nil {
+		return []string{}, nil
+	}
+
+	a.mu.Lock()
+	cached, ok := a.cache[userID]
+	a.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.roles, cached.err
+	}
+
+	cached = cachedAccessTokenUser{expiresAt: now.Add(accessTokenUserCacheTTL)}
+	foundUser, err := a.users.GetUser(ctx, userID)
+	switch {
+	case errors.Is(err, identity.ErrUserNotFound):
+		cached.err = errors.New("the user of the personal access token no longer exists")
+	case err != nil:
+		return nil, fmt.Errorf("%w: %v", ErrAuthenticatorUnavailable, err)
+	case !foundUser.Enabled:
+		cached.err = errors.New("the user of the personal access token is disabled")
+	default:
+		roles, err := a.users.GetUserRoles(ctx, userID, a.clientIDs)
+		if errors.Is(err, identity.ErrUserNotFound) {
+			cached.err = errors.New("the user of the personal access token no longer exists")
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrAuthenticatorUnavailable, err)
+		}
+		cached.roles = roles
+	}
+
+	a.mu.Lock()
+	a.cache[userID] = cached
+	a.mu.Unlock()
+	return cached.roles, cached.err
+}
+
+// ScopedAccessTokens lets personal access tokens with scopes use the routes, if they have readScope for GET requests,
+// and writeScope for other requests. Must be used before JWTProtected, which rejects scoped tokens on all other routes
+func ScopedAccessTokens(readScope string, writeScope string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := writeScope
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				scope = readScope
+			}
+			ctx := context.WithValue(r.Context(), userContextKey("accessTokenScope"), scope)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// authorizeAccessToken checks that personal access tokens with scopes have the scope the route requires for them
+func authorizeAccessToken(w http.ResponseWriter, r *http.Request, grants *Grants) bool {
+	if !grants.PersonalAccessToken || len(grants.Scopes) == 0 {
+		return true
+	}
+	scope, ok := r.Context().Value(userContextKey("accessTokenScope")).(string)
+	if !ok || scope == "" {
+		forbidden(w, "Personal access tokens with scopes cannot be used for this route", nil)
+		return false
+	}
+	if !containsAny(grants.Scopes, []string{scope}) {
+		forbidden(w, fmt.Sprintf("Missing scopes: %v", scope), []string{scope})
+		return false
+	}
+	return true
+}
+
+// RejectAccessTokens only lets requests through that are not authenticated with a personal access token,
+// e.g. so tokens cannot be used to create more tokens. Must be used after JWTProtected
+func RejectAccessTokens() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			grants := GrantsFromContext(r.Context())
+			if grants == nil {
+				unauthorized(w, r, "Missing token")
+				return
+			}
+			if grants.PersonalAccessToken {
+				forbidden(w, "Personal access tokens cannot be used for this route", nil)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package middleware
+
+import (
+	"ShoppingList-Backend/internal/pkg/user"
+	"ShoppingList-Backend/pkg/config"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"go.uber.org/zap"
+)
+
+const (
+	AuthModeJWKS      = "jwks"
+	AuthModePublicKey = "publickey"
+	AuthModeHMAC      = "hmac"
+	AuthModeDev       = "dev"
+)
+
+var headerXDevUserId = http.CanonicalHeaderKey("X-Dev-User-ID")
+
+var (
+	// ErrNoCredentials is returned by authenticators when the request has no credentials at all
+	ErrNoCredentials = errors.New("no credentials")
+	// ErrAuthenticatorUnavailable is returned by authenticators when the credentials could not be checked,
+	// e.g. because the JWKS could not be fetched
+	ErrAuthenticatorUnavailable = errors.New("authenticator unavailable")
+)
+
+// Identity is who a request was authenticated as
+type Identity struct {
+	UserID string
+	Claims *user.Claims
+	Grants *Grants
+}
+
+// Authenticator finds the identity of the caller of a request. Errors other than ErrNoCredentials and
+// ErrAuthenticatorUnavailable mean the credentials are invalid
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Identity, error)
+}
+
+// NewAuthenticator creates the authenticator selected by the auth mode of the configuration.
+// Dev mode is refused unless it is explicitly enabled, and always in production
+func NewAuthenticator(cfg *config.Config) (Authenticator, error) {
+	validation := claimsValidation{
+		issuer:    cfg.JwtIssuer,
+		audiences: cfg.GetJwtAudiences(),
+		leeway:    time.Duration(cfg.JwtLeeway) * time.Second,
+	}
+
+	switch cfg.GetAuthMode() {
+	case AuthModeJWKS:
+		return newJWTAuthenticator(sharedJWKS(cfg.JwtJwksUrl).keys, algorithmsOrDefault(cfg, "RS256"), validation), nil
+
+	case AuthModePublicKey:
+		publicKey, err := readPublicKey(cfg.JwtPublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		defaultAlgorithm := "RS256"
+		if _, ok := publicKey.(*ecdsa.PublicKey); ok {
+			defaultAlgorithm = "ES256"
+		}
+		keys := func() (jwt.Keyfunc, error) {
+			return func(token *jwt.Token) (interface{}, error) {
+				switch token.Method.(type) {
+				case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS, *jwt.SigningMethodECDSA:
+					return publicKey, nil
+				}
+				return nil, fmt.Errorf("signing method %v is not accepted", token.Method.Alg())
+			}, nil
+		}
+		return newJWTAuthenticator(keys, algorithmsOrDefault(cfg, defaultAlgorithm), validation), nil
+
+	case AuthModeHMAC:
+		secret := cfg.GetJwtHmacSecret()
+		if len(secret) < 32 {
+			return nil, fmt.Errorf("auth mode %v requires a JWT_HMAC_SECRET of at least 32 bytes", AuthModeHMAC)
+		}
+		keys := func() (jwt.Keyfunc, error) {
+			return func(token *jwt.Token) (interface{}, error) {
+				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+					return nil, fmt.Errorf("signing method %v is not accepted", token.Method.Alg())
+				}
+				return secret, nil
+			}, nil
+		}
+		return newJWTAuthenticator(keys, algorithmsOrDefault(cfg, "HS256"), validation), nil
+
+	case AuthModeDev:
+		if !cfg.AuthDevModeEnabled {
+			return nil, fmt.Errorf("auth mode %v must be explicitly enabled with AUTH_DEV_MODE_ENABLED=true", AuthModeDev)
+		}
+		if cfg.IsProduction() {
+			return nil, fmt.Errorf("auth mode %v is not allowed in production", AuthModeDev)
+		}
+		zap.S().Warnf("Dev authentication is enabled. Requests are trusted to be from the user in the %v header", headerXDevUserId)
+		return &devAuthenticator{
+			grants: &Grants{
+				Scopes: cfg.GetJwtRequiredScopes(),
+				Roles:  cfg.GetJwtRequiredRoles(),
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown auth mode %v", cfg.GetAuthMode())
+}
+
+func algorithmsOrDefault(cfg *config.Config, defaultAlgorithm string) []string {
+	algorithms := cfg.GetJwtAlgorithms()
+	if len(algorithms) == 0 {
+		return []string{defaultAlgorithm}
+	}
+	return algorithms
+}
+
+func readPublicKey(file string) (interface{}, error) {
+	if file == "" {
+		return nil, fmt.Errorf("auth mode %v requires JWT_PUBLIC_KEY_FILE", AuthModePublicKey)
+	}
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read public key file %v: %w", file, err)
+	}
+	if rsaKey, err := jwt.ParseRSAPublicKeyFromPEM(pem); err == nil {
+		return rsaKey, nil
+	}
+	if ecKey, err := jwt.ParseECPublicKeyFromPEM(pem); err == nil {
+		return ecKey, nil
+	}
+	return nil, fmt.Errorf("public key file %v does not contain a PEM encoded RSA or ECDSA public key", file)
+}
+
+// jwtAuthenticator authenticates requests with a bearer token, verified with the keys it is given
+type jwtAuthenticator struct {
+	// keys returns the key func to verify tokens with, or an error if the keys are not available
+	keys       func() (jwt.Keyfunc, error)
+	parser     *jwt.Parser
+	validation claimsValidation
+}
+
+func newJWTAuthenticator(keys func() (jwt.Keyfunc, error), algorithms []string, validation claimsValidation) *jwtAuthenticator {
+	return &jwtAuthenticator{
+		keys: keys,
+		parser: &jwt.Parser{
+			ValidMethods: algorithms,
+			// The claims are validated by claimsValidation instead, with leeway for clock skew
+			SkipClaimsValidation: true,
+		},
+		validation: validation,
+	}
+}
+
+func (a *jwtAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	jwtB64, ok := bearerToken(r)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	keyFunc, err := a.keys()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAuthenticatorUnavailable, err)
+	}
+
+	claims := jwtClaims{}
+	token, err := a.parser.ParseWithClaims(jwtB64, &claims, keyFunc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the JWT: %w", err)
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	if err := a.validation.validate(&claims, time.Now()); err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	return &Identity{
+		UserID: claims.Subject,
+		Claims: &claims.Claims,
+		Grants: claims.grants(),
+	}, nil
+}
+
+// bearerToken gets the token from the Authorization header. Clients that cannot set headers use tickets instead, see WithTickets
+func bearerToken(r *http.Request) (string, bool) {
+	authHeader := r.Header.Get("Authorization")
+	if !strings.Contains(authHeader, "Bearer") {
+		return "", false
+	}
+	return strings.TrimSpace(strings.Split(authHeader, "Bearer")[1]), true
+}
+
+// devAuthenticator trusts the X-Dev-User-ID header to be the ID of the user. Only for running locally
+type devAuthenticator struct {
+	// Given to every request, so they pass the scopes and roles required by the configuration
+	grants *Grants
+}
+
+func (a *devAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	userID := strings.TrimSpace(r.Header.Get(headerXDevUserId))
+	if userID == "" {
+		return nil, ErrNoCredentials
+	}
+	if len(userID) > 36 {
+		return nil, fmt.Errorf("%v must be at most 36 characters", headerXDevUserId)
+	}
+	return &Identity{
+		UserID: userID,
+		Claims: &user.Claims{
+			Name:              userID,
+			PreferredUsername: userID,
+		},
+		Grants: a.grants,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"ShoppingList-Backend/pkg/config"
+	"fmt"
+
+	"github.com/rs/cors"
+)
+
+// exposedHeaders are the response headers browsers let clients read
+var exposedHeaders = []string{
+	"X-Request-ID",
+	"ETag",
+	"RateLimit-Limit",
+	"RateLimit-Remaining",
+	"RateLimit-Reset",
+	"Retry-After",
+}
+
+// NewCors creates the CORS policy of the configuration. Allowing credentials from all origins is refused,
+// since any site could then make requests with the cookies of the user
+func NewCors(cfg *config.Config) (*cors.Cors, error) {
+	return newCors(cors.Options{
+		AllowedOrigins:   cfg.GetCorsAllowedOrigins(),
+		AllowedMethods:   cfg.GetCorsAllowedMethods(),
+		AllowedHeaders:   cfg.GetCorsAllowedHeaders(),
+		AllowCredentials: cfg.CorsAllowCredentials,
+		MaxAge:           cfg.CorsMaxAge,
+	})
+}
+
+func newCors(options cors.Options) (*cors.Cors, error) {
+	if options.AllowCredentials {
+		for _, origin := range options.AllowedOrigins {
+			if origin == "*" {
+				return nil, fmt.Errorf("CORS credentials cannot be allowed for all origins")
+			}
+		}
+	}
+
+	options.ExposedHeaders = exposedHeaders
+	options.Debug = false
+	return cors.New(options), nil
+}
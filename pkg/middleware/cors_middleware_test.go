@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/cors"
+	"github.com/urfave/negroni"
+)
+
+const testAllowedOrigin = "https://app.example.org"
+
+// newTestCorsChain builds the chain of cmd/api: the CORS policy, then the request logger and the router
+func newTestCorsChain(t *testing.T) http.Handler {
+	t.Helper()
+	corsMiddleware, err := newCors(cors.Options{
+		AllowedOrigins:   []string{"https://*.example.org"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type", "X-Household-ID", "X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           600,
+	})
+	if err != nil {
+		t.Fatalf("could not create CORS policy: %v", err)
+	}
+
+	r := mux.NewRouter().StrictSlash(true)
+	r.HandleFunc("/api/v1/lists", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	}).Methods("GET")
+	r.PathPrefix("/socket.io/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	n := negroni.New(corsMiddleware)
+	n.UseHandler(NewZapLogger()(r))
+	return n
+}
+
+func preflight(handler http.Handler, path string, origin string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodOptions, path, nil)
+	r.Header.Set("Origin", origin)
+	r.Header.Set("Access-Control-Request-Method", "GET")
+	r.Header.Set("Access-Control-Request-Headers", "Authorization, X-Household-ID")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func TestCorsPreflightFromAllowedOrigin(t *testing.T) {
+	handler := newTestCorsChain(t)
+
+	for _, path := range []string{"/socket.io/", "/api/v1/lists"} {
+		w := preflight(handler, path, testAllowedOrigin)
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != testAllowedOrigin {
+			t.Errorf("%v: Access-Control-Allow-Origin is %q, want %q", path, got, testAllowedOrigin)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+			t.Errorf("%v: Access-Control-Allow-Credentials is %q, want true", path, got)
+		}
+		if got := w.Header().Get("Access-Control-Max-Age"); got != "600" {
+			t.Errorf("%v: Access-Control-Max-Age is %q, want 600", path, got)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Headers"); !strings.Contains(strings.ToLower(got), "x-household-id") {
+			t.Errorf("%v: Access-Control-Allow-Headers is %q, want X-Household-ID to be allowed", path, got)
+		}
+	}
+}
+
+func TestCorsPreflightFromDisallowedOrigin(t *testing.T) {
+	handler := newTestCorsChain(t)
+
+	// The wildcard matches subdomains only, not other sites ending in the domain
+	for _, origin := range []string{"https://evil.org", "https://example.org.evil.org"} {
+		for _, path := range []string{"/socket.io/", "/api/v1/lists"} {
+			w := preflight(handler, path, origin)
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+				t.Errorf("%v from %v: Access-Control-Allow-Origin is %q, want none", path, origin, got)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+				t.Errorf("%v from %v: Access-Control-Allow-Credentials is %q, want none", path, origin, got)
+			}
+		}
+	}
+}
+
+func TestCorsExposesHeaders(t *testing.T) {
+	handler := newTestCorsChain(t)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/lists", nil)
+	r.Header.Set("Origin", testAllowedOrigin)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status is %v, want %v", w.Code, http.StatusOK)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != testAllowedOrigin {
+		t.Errorf("Access-Control-Allow-Origin is %q, want %q", got, testAllowedOrigin)
+	}
+	// Header names are case insensitive, and rs/cors sends them canonicalized
+	exposed := strings.ToLower(w.Header().Get("Access-Control-Expose-Headers"))
+	for _, header := range exposedHeaders {
+		if !strings.Contains(exposed, strings.ToLower(header)) {
+			t.Errorf("Access-Control-Expose-Headers is %q, want %v to be exposed", exposed, header)
+		}
+	}
+	// The logger echoes the request ID, so clients can read it through the exposed header
+	if w.Header().Get("X-Request-ID") == "" {
+		t.Errorf("X-Request-ID was not set")
+	}
+}
+
+func TestCorsRefusesCredentialsForAllOrigins(t *testing.T) {
+	_, err := newCors(cors.Options{
+		AllowedOrigins:   []string{"https://app.example.org", "*"},
+		AllowCredentials: true,
+	})
+	if err == nil {
+		t.Fatalf("credentials were allowed for all origins")
+	}
+
+	if _, err := newCors(cors.Options{AllowedOrigins: []string{"*"}}); err != nil {
+		t.Errorf("all origins without credentials were refused: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"ShoppingList-Backend/internal/pkg/controller"
+	"ShoppingList-Backend/internal/pkg/user"
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+var headerXHouseholdId = http.CanonicalHeaderKey("X-Household-ID")
+
+type HouseholdResolver interface {
+	ResolveHousehold(userID string, householdID string) (uuid.UUID, *controller.ControllerError)
+}
+
+// HouseholdScoped sets the household of the user in the context to the one in the X-Household-ID header,
+// or the user's personal household if there is no header. Must be used after JWTProtected
+func HouseholdScoped(resolver HouseholdResolver) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			appUser := UserFromContext(r.Context())
+			if appUser == nil {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			householdID, cErr := resolver.ResolveHousehold(appUser.ID, r.Header.Get(headerXHouseholdId))
+			if cErr != nil {
+				http.Error(w, cErr.Err.Error(), cErr.StatusCode)
+				return
+			}
+
+			scopedUser := &user.AppUser{ID: appUser.ID, HouseholdID: householdID}
+			ctx := context.WithValue(r.Context(), userContextKey("user"), scopedUser)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/MicahParks/keyfunc"
+	"github.com/golang-jwt/jwt"
+	"go.uber.org/zap"
+)
+
+const (
+	// How often the keys are refreshed in the background
+	jwksRefreshInterval = time.Hour
+	// Tokens with an unknown kid trigger a refresh, at most this often
+	jwksRefreshRateLimit = 5 * time.Minute
+	jwksRefreshTimeout   = 10 * time.Second
+	// If the first fetch fails, requests fail without fetching again until this has passed
+	jwksRetryInterval = 10 * time.Second
+)
+
+// jwksCache loads the JWKS the first time it is needed, and shares it between all requests of the process.
+// A failed refresh keeps the keys that were already loaded, so tokens can still be verified while the JWKS URL is down
+type jwksCache struct {
+	url string
+	// Returns the current time. Replaced in tests
+	now func() time.Time
+
+	mu          sync.Mutex
+	jwks        *keyfunc.JWKs
+	lastAttempt time.Time
+	lastErr     error
+}
+
+var jwksCaches = struct {
+	sync.Mutex
+	byURL map[string]*jwksCache
+}{byURL: map[string]*jwksCache{}}
+
+// sharedJWKS returns the cache of the JWKS at the URL, which is created once per process
+func sharedJWKS(url string) *jwksCache {
+	jwksCaches.Lock()
+	defer jwksCaches.Unlock()
+	cache, ok := jwksCaches.byURL[url]
+	if !ok {
+		cache = &jwksCache{url: url, now: time.Now}
+		jwksCaches.byURL[url] = cache
+	}
+	return cache
+}
+
+// keys returns the key func of the JWKS, for jwtAuthenticator
+func (c *jwksCache) keys() (jwt.Keyfunc, error) {
+	jwks, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	return jwks.KeyFunc, nil
+}
+
+func (c *jwksCache) get() (*keyfunc.JWKs, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.jwks != nil {
+		return c.jwks, nil
+	}
+	if c.lastErr != nil && c.now().Sub(c.lastAttempt) < jwksRetryInterval {
+		return nil, c.lastErr
+	}
+
+	refreshInterval := jwksRefreshInterval
+	refreshRateLimit := jwksRefreshRateLimit
+	refreshTimeout := jwksRefreshTimeout
+	refreshUnknownKID := true
+	options := keyfunc.Options{
+		RefreshInterval:   &refreshInterval,
+		RefreshRateLimit:  &refreshRateLimit,
+		RefreshTimeout:    &refreshTimeout,
+		RefreshUnknownKID: &refreshUnknownKID,
+		RefreshErrorHandler: func(err error) {
+			zap.S().Errorf("There was an error refreshing the JWKS, keeping the previous keys. Error: %v", err)
+		},
+	}
+
+	c.lastAttempt = c.now()
+	jwks, err := keyfunc.Get(c.url, options)
+	if err != nil {
+		c.lastErr = fmt.Errorf("could not get JWKS from %v: %w", c.url, err)
+		return nil, c.lastErr
+	}
+	c.jwks = jwks
+	c.lastErr = nil
+	return c.jwks, nil
+}
@@ -0,0 +1,236 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// testJWKSServer serves the public keys it has as a JWKS, and counts how often it is fetched
+type testJWKSServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	keys    map[string]*rsa.PublicKey
+	failing bool
+	fetches int32
+}
+
+func newTestJWKSServer(t *testing.T) *testJWKSServer {
+	t.Helper()
+	srv := &testJWKSServer{keys: map[string]*rsa.PublicKey{}}
+	srv.Server = httptest.NewServer(http.HandlerFunc(srv.serveJWKS))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func (s *testJWKSServer) serveJWKS(w http.ResponseWriter, r *http.Request) {
+	atomic.AddInt32(&s.fetches, 1)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failing {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	keys := []map[string]string{}
+	for kid, key := range s.keys {
+		keys = append(keys, map[string]string{
+			"kid": kid,
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+}
+
+func (s *testJWKSServer) addKey(kid string, key *rsa.PrivateKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[kid] = &key.PublicKey
+}
+
+func (s *testJWKSServer) setFailing(failing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failing = failing
+}
+
+func (s *testJWKSServer) fetchCount() int {
+	return int(atomic.LoadInt32(&s.fetches))
+}
+
+func newTestKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("could not generate key: %v", err)
+	}
+	return key
+}
+
+// newTestJWKSCache creates a cache of its own, so the tests do not share keys through sharedJWKS
+func newTestJWKSCache(t *testing.T, url string) *jwksCache {
+	cache := &jwksCache{url: url, now: time.Now}
+	t.Cleanup(func() {
+		if cache.jwks != nil {
+			cache.jwks.EndBackground()
+		}
+	})
+	return cache
+}
+
+func signedRequest(t *testing.T, key *rsa.PrivateKey, kid string) *http.Request {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub": "user-1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("could not sign token: %v", err)
+	}
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/lists", nil)
+	r.Header.Set("Authorization", "Bearer "+signed)
+	return r
+}
+
+func authenticateWith(cache *jwksCache, r *http.Request) error {
+	_, err := newJWTAuthenticator(cache.keys, []string{"RS256"}, claimsValidation{}).Authenticate(r)
+	return err
+}
+
+func TestSharedJWKSLoadsLazilyOncePerURL(t *testing.T) {
+	srv := newTestJWKSServer(t)
+	key := newTestKey(t)
+	srv.addKey("key-1", key)
+
+	cache := sharedJWKS(srv.URL)
+	t.Cleanup(func() {
+		if cache.jwks != nil {
+			cache.jwks.EndBackground()
+		}
+	})
+	if got := sharedJWKS(srv.URL); got != cache {
+		t.Fatalf("sharedJWKS returned a new cache for the same URL")
+	}
+	if srv.fetchCount() != 0 {
+		t.Fatalf("JWKS was fetched %v times before it was needed", srv.fetchCount())
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := authenticateWith(cache, signedRequest(t, key, "key-1")); err != nil {
+			t.Fatalf("request %v was not authenticated: %v", i, err)
+		}
+	}
+	if srv.fetchCount() != 1 {
+		t.Fatalf("JWKS was fetched %v times, want 1", srv.fetchCount())
+	}
+}
+
+func TestJWKSRefetchesUnknownKIDOnceWithinRateLimit(t *testing.T) {
+	srv := newTestJWKSServer(t)
+	oldKey := newTestKey(t)
+	srv.addKey("old", oldKey)
+	cache := newTestJWKSCache(t, srv.URL)
+
+	if err := authenticateWith(cache, signedRequest(t, oldKey, "old")); err != nil {
+		t.Fatalf("request was not authenticated: %v", err)
+	}
+
+	// The key is rotated after the JWKS was loaded, so the first token with it triggers a refetch
+	newKey := newTestKey(t)
+	srv.addKey("new", newKey)
+	if err := authenticateWith(cache, signedRequest(t, newKey, "new")); err != nil {
+		t.Fatalf("request with rotated key was not authenticated: %v", err)
+	}
+	if srv.fetchCount() != 2 {
+		t.Fatalf("JWKS was fetched %v times, want 2", srv.fetchCount())
+	}
+
+	// Within jwksRefreshRateLimit, unknown kids are rejected without fetching again
+	unknownKey := newTestKey(t)
+	for i := 0; i < 3; i++ {
+		if err := authenticateWith(cache, signedRequest(t, unknownKey, "unknown")); err == nil {
+			t.Fatalf("request with unknown kid was authenticated")
+		}
+	}
+	if srv.fetchCount() != 2 {
+		t.Fatalf("JWKS was fetched %v times after unknown kids, want 2", srv.fetchCount())
+	}
+}
+
+func TestJWKSKeepsKeysWhenServerFails(t *testing.T) {
+	srv := newTestJWKSServer(t)
+	key := newTestKey(t)
+	srv.addKey("key-1", key)
+	cache := newTestJWKSCache(t, srv.URL)
+
+	if err := authenticateWith(cache, signedRequest(t, key, "key-1")); err != nil {
+		t.Fatalf("request was not authenticated: %v", err)
+	}
+
+	// An unknown kid makes the cache refresh against the failing server
+	srv.setFailing(true)
+	if err := authenticateWith(cache, signedRequest(t, newTestKey(t), "unknown")); err == nil {
+		t.Fatalf("request with unknown kid was authenticated")
+	}
+	if srv.fetchCount() != 2 {
+		t.Fatalf("JWKS was fetched %v times, want 2", srv.fetchCount())
+	}
+
+	if err := authenticateWith(cache, signedRequest(t, key, "key-1")); err != nil {
+		t.Fatalf("request was not authenticated with the cached keys: %v", err)
+	}
+}
+
+func TestJWKSRetriesFailedFirstLoadAfterInterval(t *testing.T) {
+	srv := newTestJWKSServer(t)
+	key := newTestKey(t)
+	srv.addKey("key-1", key)
+	srv.setFailing(true)
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	cache := newTestJWKSCache(t, srv.URL)
+	cache.now = func() time.Time { return now }
+
+	err := authenticateWith(cache, signedRequest(t, key, "key-1"))
+	if err == nil {
+		t.Fatalf("request was authenticated without keys")
+	}
+	if srv.fetchCount() != 1 {
+		t.Fatalf("JWKS was fetched %v times, want 1", srv.fetchCount())
+	}
+
+	// Until the retry interval has passed, requests fail without fetching, even if the server is back
+	srv.setFailing(false)
+	now = now.Add(jwksRetryInterval - time.Second)
+	if err := authenticateWith(cache, signedRequest(t, key, "key-1")); err == nil {
+		t.Fatalf("request was authenticated before the retry interval had passed")
+	}
+	if srv.fetchCount() != 1 {
+		t.Fatalf("JWKS was fetched %v times within the retry interval, want 1", srv.fetchCount())
+	}
+
+	now = now.Add(time.Second)
+	if err := authenticateWith(cache, signedRequest(t, key, "key-1")); err != nil {
+		t.Fatalf("request was not authenticated after the retry interval: %v", err)
+	}
+	if srv.fetchCount() != 2 {
+		t.Fatalf("JWKS was fetched %v times, want 2", srv.fetchCount())
+	}
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"ShoppingList-Backend/internal/pkg/user"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// audience is the aud claim, which is either a string or an array of strings
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("aud must be a string or an array of strings")
+	}
+	*a = multiple
+	return nil
+}
+
+type roles struct {
+	Roles []string `json:"roles"`
+}
+
+type jwtClaims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+	IssuedAt  int64    `json:"iat"`
+
+	// Space separated scopes
+	Scope string `json:"scope"`
+	// Keycloak realm roles, and roles of each client
+	RealmAccess    roles            `json:"realm_access"`
+	ResourceAccess map[string]roles `json:"resource_access"`
+
+	user.Claims
+}
+
+// Valid is called by the JWT parser. The claims are validated against the configuration by validate instead
+func (c jwtClaims) Valid() error {
+	return nil
+}
+
+// claimsValidation is what a token must satisfy, apart from its signature
+type claimsValidation struct {
+	issuer    string
+	audiences []string
+	leeway    time.Duration
+}
+
+func (v claimsValidation) validate(c *jwtClaims, now time.Time) error {
+	if c.Subject == "" {
+		return errors.New("token has no subject")
+	}
+	if c.ExpiresAt == 0 {
+		return errors.New("token has no expiry")
+	}
+	if now.After(time.Unix(c.ExpiresAt, 0).Add(v.leeway)) {
+		return errors.New("token is expired")
+	}
+	if c.NotBefore != 0 && now.Add(v.leeway).Before(time.Unix(c.NotBefore, 0)) {
+		return errors.New("token is not valid yet")
+	}
+	if c.IssuedAt != 0 && now.Add(v.leeway).Before(time.Unix(c.IssuedAt, 0)) {
+		return errors.New("token is issued in the future")
+	}
+	if v.issuer != "" && c.Issuer != v.issuer {
+		return fmt.Errorf("token issuer %v is not accepted", c.Issuer)
+	}
+	if len(v.audiences) > 0 && !containsAny(c.Audience, v.audiences) {
+		return fmt.Errorf("token audience %v is not accepted", strings.Join(c.Audience, ", "))
+	}
+	return nil
+}
+
+// Grants are the scopes and roles of the token the request was authenticated with
+type Grants struct {
+	Scopes []string
+	// Realm roles as is, and client roles as client:role
+	Roles []string
+	// Set if the request was authenticated with a personal access token. Tokens with scopes can only use the routes
+	// their scopes allow, tokens without have the same access as the user
+	PersonalAccessToken bool
+}
+
+func (c *jwtClaims) grants() *Grants {
+	grants := &Grants{
+		Scopes: strings.Fields(c.Scope),
+		Roles:  append([]string{}, c.RealmAccess.Roles...),
+	}
+	for client, clientRoles := range c.ResourceAccess {
+		for _, role := range clientRoles.Roles {
+			grants.Roles = append(grants.Roles, client+":"+role)
+		}
+	}
+	return grants
+}
+
+// missing returns the required values that are not in granted
+func missing(required []string, granted []string) []string {
+	missing := []string{}
+	for _, r := range required {
+		if !containsAny(granted, []string{r}) {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+func containsAny(values []string, wanted []string) bool {
+	for _, value := range values {
+		for _, w := range wanted {
+			if value == w {
+				return true
+			}
+		}
+	}
+	return false
+}
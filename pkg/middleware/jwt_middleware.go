@@ -4,14 +4,11 @@ import (
 	"ShoppingList-Backend/internal/pkg/user"
 	"ShoppingList-Backend/pkg/config"
 	"context"
-	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
-	"time"
 
-	"github.com/MicahParks/keyfunc"
-	"github.com/golang-jwt/jwt"
 	"github.com/gorilla/mux"
 	"go.uber.org/zap"
 )
@@ -32,59 +29,122 @@ func SetContextUser(ctx context.Context, userId string) context.Context {
 	return newCtx
 }
 
-func JWTProtected(cfg *config.Config) mux.MiddlewareFunc {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			logger := zap.S()
+// ClaimsFromContext returns the profile claims of the JWT the request was authenticated with
+func ClaimsFromContext(ctx context.Context) *user.Claims {
+	claims, ok := ctx.Value(userContextKey("claims")).(*user.Claims)
+	if ok {
+		return claims
+	}
+	return nil
+}
 
-			refreshInterval := time.Hour
+// GrantsFromContext returns the scopes and roles of the JWT the request was authenticated with
+func GrantsFromContext(ctx context.Context) *Grants {
+	grants, ok := ctx.Value(userContextKey("grants")).(*Grants)
+	if ok {
+		return grants
+	}
+	return nil
+}
 
-			options := keyfunc.Options{
-				RefreshInterval: &refreshInterval,
-				RefreshErrorHandler: func(err error) {
-					logger.Errorf("There was an error with the jwt.KeyFunc. Error: %v", err)
-				},
+// unauthorized responds that the request must be authenticated with a valid token
+func unauthorized(w http.ResponseWriter, r *http.Request, reason string) {
+	r.Header.Add("X-Error-Reason", reason)
+	w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="invalid_token", error_description=%q`, reason))
+	http.Error(w, reason, http.StatusUnauthorized)
+}
+
+// forbidden responds that the token is valid, but does not grant access
+func forbidden(w http.ResponseWriter, reason string, scopes []string) {
+	if len(scopes) > 0 {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope=%q`, strings.Join(scopes, " ")))
+	}
+	http.Error(w, reason, http.StatusForbidden)
+}
+
+// authorize checks that the grants include all the required scopes and roles, responding with 401 or 403 if not
+func authorize(w http.ResponseWriter, r *http.Request, requiredScopes []string, requiredRoles []string) bool {
+	grants := GrantsFromContext(r.Context())
+	if grants == nil {
+		unauthorized(w, r, "Missing token")
+		return false
+	}
+	if missingScopes := missing(requiredScopes, grants.Scopes); len(missingScopes) > 0 {
+		forbidden(w, fmt.Sprintf("Missing scopes: %v", strings.Join(missingScopes, ", ")), missingScopes)
+		return false
+	}
+	if missingRoles := missing(requiredRoles, grants.Roles); len(missingRoles) > 0 {
+		forbidden(w, fmt.Sprintf("Missing roles: %v", strings.Join(missingRoles, ", ")), nil)
+		return false
+	}
+	return true
+}
+
+// RequireScopes only lets requests through if their token has all the scopes. Must be used after JWTProtected
+func RequireScopes(scopes ...string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if authorize(w, r, scopes, nil) {
+				next.ServeHTTP(w, r)
 			}
+		})
+	}
+}
 
-			jwks, err := keyfunc.Get(cfg.JwtJwksUrl, options)
-			if err != nil {
-				logger.Errorf("Failed to create JWKS from resource at the given URL. Error: %v", err)
-				http.Error(w, "Could not create JWKS", http.StatusInternalServerError)
-				return
+// RequireRoles only lets requests through if their token has all the roles. Realm roles are given as is,
+// and client roles as client:role. Must be used after JWTProtected
+func RequireRoles(roles ...string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if authorize(w, r, nil, roles) {
+				next.ServeHTTP(w, r)
 			}
+		})
+	}
+}
 
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
-				authHeaderQueryParam := r.URL.Query().Get("Authorization")
-				authHeaderBytes, err := base64.StdEncoding.DecodeString(authHeaderQueryParam)
-				if err == nil {
-					authHeader = string(authHeaderBytes)
-				}
+// JWTProtected authenticates the request with the authenticator, responding with 401 if the credentials are missing or invalid.
+// The identity must also have the scopes and roles required by the configuration, or the response is 403.
+// Personal access tokens must have the required roles, but not the scopes.
+// Personal access tokens with scopes are rejected with 403, unless the route allows them with ScopedAccessTokens
+func JWTProtected(cfg *config.Config, authenticator Authenticator) mux.MiddlewareFunc {
+	requiredScopes := cfg.GetJwtRequiredScopes()
+	requiredRoles := cfg.GetJwtRequiredRoles()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity, err := authenticator.Authenticate(r)
+			if errors.Is(err, ErrNoCredentials) {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
 			}
-			if !strings.Contains(authHeader, "Bearer") {
-				http.Error(w, "Forbidden", http.StatusForbidden)
+			if errors.Is(err, ErrAuthenticatorUnavailable) {
+				zap.S().Errorf("Could not authenticate request. Error: %v", err)
+				http.Error(w, "Could not authenticate request", http.StatusInternalServerError)
 				return
 			}
-
-			jwtB64 := strings.TrimSpace(strings.Split(authHeader, "Bearer")[1])
-			claims := jwt.StandardClaims{}
-			token, err := jwt.ParseWithClaims(jwtB64, &claims, jwks.KeyFunc)
 			if err != nil {
-				errorMsg := fmt.Sprintf("Failed to parse the JWT. Error: %v", err)
-				r.Header.Add("X-Error-Reason", errorMsg)
-				http.Error(w, errorMsg, http.StatusUnauthorized)
+				unauthorized(w, r, fmt.Sprintf("Unauthorized: %v", err))
 				return
 			}
 
-			if !token.Valid {
-				r.Header.Add("X-Error-Reason", "Invalid token")
-				http.Error(w, "Invalid token", http.StatusUnauthorized)
+			ctx := SetContextUser(r.Context(), identity.UserID)
+			ctx = context.WithValue(ctx, userContextKey("claims"), identity.Claims)
+			ctx = context.WithValue(ctx, userContextKey("grants"), identity.Grants)
+			r = r.WithContext(ctx)
+
+			// The required scopes are OAuth scopes of JWTs, which personal access tokens do not have.
+			// Their roles are the current roles of the user, so the required roles are checked for them as well
+			if identity.Grants.PersonalAccessToken {
+				if !authorize(w, r, nil, requiredRoles) || !authorizeAccessToken(w, r, identity.Grants) {
+					return
+				}
+			} else if !authorize(w, r, requiredScopes, requiredRoles) {
 				return
 			}
 
-			ctx := SetContextUser(r.Context(), claims.Subject)
-
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, r)
 
 		})
 	}
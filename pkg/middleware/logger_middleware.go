@@ -18,7 +18,7 @@ import (
 type Config struct {
 	// Next defines a function to skip this middleware when returned true
 	// Optional. Default: nil
-	// Next func(c *fiber.Ctx) bool
+	Next func(r *http.Request) bool
 
 	// TimeFormat https://pkg.go.dev/time#Time.Format
 	//
@@ -115,6 +115,10 @@ func NewZapLogger(config ...Config) func(http.Handler) http.Handler {
 
 	return func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
+			if cfg.Next != nil && cfg.Next(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
 			start := time.Now()
 
 			// Get/Set request id
@@ -128,6 +132,9 @@ func NewZapLogger(config ...Config) func(http.Handler) http.Handler {
 				requestId = requestIds[0]
 			}
 
+			// The request id is returned, so clients can refer to the request
+			w.Header().Set(headerXRequestId, requestId)
+
 			// responseWriter is wrapped, so status can be inspected after it has been sent
 			wrapped := wrapResponseWriter(w)
 
@@ -175,7 +182,8 @@ func NewZapLogger(config ...Config) func(http.Handler) http.Handler {
 				defer logger.Sync()
 				// TODO: make fields configurable
 
-				url := r.URL
+				// Copied, so redacting does not change the request
+				url := *r.URL
 				query := url.Query()
 				for _, param := range cfg.RedactedQueryParams {
 					if query.Has(param) {
@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"ShoppingList-Backend/pkg/config"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// tokenBucketScript takes a token from the bucket in KEYS[1], if it has one, after refilling it for the time since it was last used.
+// ARGV is the rate in tokens per second, the burst and the current time in milliseconds.
+// Returns whether a token was taken, and the tokens left as a string, since redis truncates numbers to integers
+var tokenBucketScript = redis.NewScript(1, `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) / 1000 * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+-- The bucket is full again after this, so it does not need to be kept
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// RateLimiter limits requests with token buckets in redis, so the limits are shared by all API replicas
+type RateLimiter struct {
+	redis             *redis.Pool
+	prefix            string
+	cfg               *config.Config
+	trustForwardedFor bool
+}
+
+func NewRateLimiter(pool *redis.Pool, cfg *config.Config) *RateLimiter {
+	return &RateLimiter{
+		redis:             pool,
+		prefix:            cfg.GetRedisPrefix(),
+		cfg:               cfg,
+		trustForwardedFor: cfg.RateLimitTrustForwardedFor,
+	}
+}
+
+type rateLimitResult struct {
+	allowed   bool
+	remaining int
+	// Until a request is allowed again
+	retryAfter time.Duration
+	// Until the bucket is full again
+	reset time.Duration
+}
+
+func (l *RateLimiter) take(key string, limit config.RateLimit, now time.Time) (rateLimitResult, error) {
+	conn := l.redis.Get()
+	defer conn.Close()
+
+	reply, err := redis.Values(tokenBucketScript.Do(conn, key, limit.Rate, limit.Burst, now.UnixMilli()))
+	if err != nil {
+		return rateLimitResult{}, err
+	}
+	var allowed int
+	var tokensStr string
+	if _, err := redis.Scan(reply, &allowed, &tokensStr); err != nil {
+		return rateLimitResult{}, err
+	}
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return rateLimitResult{}, err
+	}
+
+	result := rateLimitResult{
+		allowed:   allowed == 1,
+		remaining: int(math.Floor(tokens)),
+		reset:     time.Duration((float64(limit.Burst) - tokens) / limit.Rate * float64(time.Second)),
+	}
+	if !result.allowed {
+		result.retryAfter = time.Duration((1 - tokens) / limit.Rate * float64(time.Second))
+	}
+	return result, nil
+}
+
+// clientIP returns the IP of the client, which is the first X-Forwarded-For address if it is trusted
+func (l *RateLimiter) clientIP(r *http.Request) string {
+	if l.trustForwardedFor {
+		if forwardedFor := r.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+			first, _, _ := strings.Cut(forwardedFor, ",")
+			if ip := strings.TrimSpace(first); ip != "" {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// seconds rounds the duration up to whole seconds, for headers
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// RateLimited limits the requests to the routes with the limit configured for the group.
+// Authenticated requests are limited per user, so it must be used after JWTProtected on protected routes.
+// Other requests are limited per IP. If redis is unavailable, requests are let through
+func RateLimited(limiter *RateLimiter, group string) mux.MiddlewareFunc {
+	limit := limiter.cfg.GetRateLimit(group)
+
+	return func(next http.Handler) http.Handler {
+		if limit.Rate <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := fmt.Sprintf("%v.ratelimit.%v.ip.%v", limiter.prefix, group, limiter.clientIP(r))
+			if appUser := UserFromContext(r.Context()); appUser != nil {
+				key = fmt.Sprintf("%v.ratelimit.%v.user.%v", limiter.prefix, group, appUser.ID)
+			}
+
+			result, err := limiter.take(key, limit, time.Now())
+			if err != nil {
+				zap.S().Warnw("Could not check rate limit, letting the request through", "group", group, "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.remaining))
+			w.Header().Set("RateLimit-Reset", seconds(result.reset))
+			if !result.allowed {
+				w.Header().Set("Retry-After", seconds(result.retryAfter))
+				http.Error(w, "Too many requests", http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"ShoppingList-Backend/internal/pkg/controller"
+	"ShoppingList-Backend/internal/pkg/ticket"
+	"fmt"
+	"net/http"
+)
+
+type TicketRedeemer interface {
+	RedeemTicket(ticketParam string) (*ticket.Holder, *controller.ControllerError)
+}
+
+// WithTickets accepts single-use tickets in the ticket query parameter, and passes all other requests on to the authenticator.
+// Only for routes where clients cannot send an Authorization header, since query parameters end up in access logs
+func WithTickets(authenticator Authenticator, redeemer TicketRedeemer) Authenticator {
+	return &ticketAuthenticator{
+		next:     authenticator,
+		redeemer: redeemer,
+	}
+}
+
+type ticketAuthenticator struct {
+	next     Authenticator
+	redeemer TicketRedeemer
+}
+
+func (a *ticketAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	ticketParam := r.URL.Query().Get("ticket")
+	if ticketParam == "" {
+		return a.next.Authenticate(r)
+	}
+
+	holder, cErr := a.redeemer.RedeemTicket(ticketParam)
+	if cErr != nil {
+		if cErr.StatusCode >= http.StatusInternalServerError {
+			return nil, fmt.Errorf("%w: %v", ErrAuthenticatorUnavailable, cErr.Err)
+		}
+		return nil, cErr.Err
+	}
+
+	return &Identity{
+		UserID: holder.UserID,
+		Claims: nil,
+		Grants: &Grants{
+			Scopes:              holder.Scopes,
+			Roles:               holder.Roles,
+			PersonalAccessToken: holder.PersonalAccessToken,
+		},
+	}, nil
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"ShoppingList-Backend/internal/pkg/controller"
+	"ShoppingList-Backend/internal/pkg/user"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type UserSyncer interface {
+	SyncUser(userID string, claims user.Claims) *controller.ControllerError
+}
+
+// UserSynced creates or updates the profile of the user from the claims of the JWT. Must be used after JWTProtected
+func UserSynced(syncer UserSyncer) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			appUser := UserFromContext(r.Context())
+			if appUser == nil {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			// Requests authenticated with a personal access token have no claims, and the user already exists
+			claims := ClaimsFromContext(r.Context())
+			if claims == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if cErr := syncer.SyncUser(appUser.ID, *claims); cErr != nil {
+				http.Error(w, cErr.Err.Error(), cErr.StatusCode)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -3,6 +3,11 @@ package validation
 // TODO: fix bad package name
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
@@ -10,6 +15,15 @@ import (
 func NewValidator() *validator.Validate {
 	validate := validator.New()
 
+	// Report fields by their JSON names, which are the ones clients know
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
 	_ = validate.RegisterValidation("uuid", func(fl validator.FieldLevel) bool {
 		field := fl.Field().String()
 		if _, err := uuid.Parse(field); err != nil {
@@ -26,11 +40,17 @@ func ValidatorErrors(err error) map[string]string {
 	fields := map[string]string{}
 
 	// Make error message for each invalid field.
-	// for _, err := range err.(validator.ValidationErrors) {
-	// 	fields[err.Field()] = err.???
-	// }
-	// TODO: couldnt make this work for some reason
-	// https://github.com/koddr/tutorial-go-fiber-rest-api/blob/05be4c0db3/pkg/utils/validator.go
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return fields
+	}
+	for _, err := range validationErrors {
+		if err.Param() != "" {
+			fields[err.Field()] = fmt.Sprintf("must satisfy %v=%v, got %v", err.Tag(), err.Param(), err.Value())
+		} else {
+			fields[err.Field()] = fmt.Sprintf("must satisfy %v, got %v", err.Tag(), err.Value())
+		}
+	}
 
 	return fields
 }
@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/gocraft/work"
+	"go.uber.org/zap"
+)
+
+func (c *WorkerContext) AutoClearCrossedListItems(job *work.Job) error {
+	now := time.Now()
+	lists, err := c.App.Queries.List.GetListsToAutoClear(now)
+	if err != nil {
+		zap.S().Errorf("Could not get lists to auto-clear: %v", err)
+		return err
+	}
+
+	clearedCount := 0
+	for _, list := range lists {
+		clearedIDs, err := c.App.Controllers.List.AutoClearCrossedListItems(list, now)
+		if err != nil {
+			zap.S().Errorw("Could not auto-clear list", "listID", list.ID, "error", err)
+			continue
+		}
+		clearedCount += len(clearedIDs)
+	}
+
+	zap.S().Infow("Finished job", "job name", job.Name, "lists", len(lists), "cleared list items", clearedCount)
+	return nil
+}
@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"ShoppingList-Backend/pkg/application"
+	"ShoppingList-Backend/pkg/health"
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// NewHealthServer serves the liveness and readiness probes of the worker.
+// The web UI cannot have routes added, so they are served on a port of their own
+func NewHealthServer(app *application.Application) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", health.LivenessHandler())
+	mux.HandleFunc("/readyz", health.ReadinessHandler(app.Readiness))
+	return &http.Server{
+		Addr:         app.Cfg.GetWorkerHealthPort(),
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 10 * time.Second,
+		Handler:      mux,
+	}
+}
+
+func StartHealthServer(server *http.Server, wg *sync.WaitGroup) {
+	defer wg.Done()
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zap.S().Errorf("Worker health server could not start: %v", err)
+		}
+	}()
+	zap.S().Infow("Worker health server started", "addr", server.Addr)
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	<-signalChan
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	server.Shutdown(ctx)
+}
@@ -1,3 +1,8 @@
 package worker
 
-const JobDemoCleanUp = "demo_clean_up"
+const (
+	JobDemoCleanUp               = "demo_clean_up"
+	JobGenerateSuggestions       = "generate_suggestions"
+	JobAddRecurringItems         = "add_recurring_items"
+	JobAutoClearCrossedListItems = "auto_clear_crossed_list_items"
+)
@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"ShoppingList-Backend/internal/pkg/recurring"
+	"ShoppingList-Backend/internal/pkg/user"
+	"time"
+
+	"github.com/gocraft/work"
+	"go.uber.org/zap"
+)
+
+func (c *WorkerContext) AddRecurringItems(job *work.Job) error {
+	now := time.Now()
+	recurringItems, err := c.App.Queries.Recurring.GetDueRecurringItems(now)
+	if err != nil {
+		zap.S().Errorf("Could not get due recurring items: %v", err)
+		return err
+	}
+
+	for _, recurringItem := range recurringItems {
+		if err := c.addRecurringItem(recurringItem); err != nil {
+			zap.S().Errorw("Could not add recurring item", "recurringItemID", recurringItem.ID, "error", err)
+		}
+
+		// Reschedule even if adding failed, so a broken rule does not run on every job
+		nextRunAt, err := recurringItem.NextRun(now)
+		if err != nil {
+			zap.S().Errorw("Could not schedule recurring item", "recurringItemID", recurringItem.ID, "error", err)
+			continue
+		}
+		if err := c.App.Queries.Recurring.SetRun(recurringItem, now, nextRunAt); err != nil {
+			zap.S().Errorf("Could not update recurring item: %v", err)
+			return err
+		}
+	}
+
+	zap.S().Infow("Finished job", "job name", job.Name, "recurring items", len(recurringItems))
+	return nil
+}
+
+// addRecurringItem adds the item to the list, unless it is already on the list and not crossed.
+// It is added through the list controller, so the budget is checked the same way as for items added through the API
+func (c *WorkerContext) addRecurringItem(recurringItem recurring.RecurringItem) error {
+	owner := &user.AppUser{ID: recurringItem.OwnerID, HouseholdID: recurringItem.HouseholdID}
+	if _, cErr := c.App.Controllers.List.AddMissingItemToList(owner, recurringItem.ListID, recurringItem.ItemID, recurringItem.Quantity); cErr != nil {
+		return cErr.Err
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"ShoppingList-Backend/internal/pkg/suggestion"
+	"time"
+
+	"github.com/gocraft/work"
+	"go.uber.org/zap"
+)
+
+func (c *WorkerContext) GenerateSuggestions(job *work.Job) error {
+	householdIDs, err := c.App.Queries.Purchase.GetPurchasingHouseholdIDs(time.Now().Add(-suggestion.PurchaseHistoryWindow))
+	if err != nil {
+		zap.S().Errorf("Could not get purchasing households: %v", err)
+		return err
+	}
+
+	for _, householdID := range householdIDs {
+		if _, err := c.App.Controllers.Suggestion.GenerateSuggestions(householdID); err != nil {
+			// Keep going, so one household does not block suggestions for everyone else
+			zap.S().Errorw("Could not generate suggestions", "householdID", householdID, "error", err)
+		}
+	}
+
+	zap.S().Infow("Finished job", "job name", job.Name, "households", len(householdIDs))
+	return nil
+}
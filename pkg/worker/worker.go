@@ -9,8 +9,8 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
-	"github.com/Nerzal/gocloak/v8"
 	"github.com/gocraft/work"
 	"go.uber.org/zap"
 )
@@ -32,6 +32,9 @@ func NewWorkerPool(app *application.Application) *work.WorkerPool {
 		return next()
 	})
 	pool.Job(JobDemoCleanUp, (*WorkerContext).CleanUpDemoUsers)
+	pool.Job(JobGenerateSuggestions, (*WorkerContext).GenerateSuggestions)
+	pool.Job(JobAddRecurringItems, (*WorkerContext).AddRecurringItems)
+	pool.Job(JobAutoClearCrossedListItems, (*WorkerContext).AutoClearCrossedListItems)
 
 	return pool
 }
@@ -54,37 +57,15 @@ func (c *WorkerContext) Log(job *work.Job, next work.NextMiddlewareFunc) error {
 }
 
 func (c *WorkerContext) CleanUpDemoUsers(job *work.Job) error {
-	client := gocloak.NewClient(c.App.Cfg.JwtKeycloakUrl)
-	ctx := context.Background()
-	token, err := client.LoginAdmin(ctx, c.App.Cfg.JwtKeycloakUsername, c.App.Cfg.JwtKeycloakPassword, "master")
-	if err != nil {
-		zap.S().Errorf("Could not login: %v", err)
-		return err
+	if c.App.Cfg.DemoUsersGroupID == "" {
+		zap.S().Warnw("No demo users group is configured, skipping job", "job name", job.Name)
+		return nil
 	}
 
-	realm := "shoppinglist"
-	demoUsersGroupId := "eab4732c-525c-4456-926d-c88b8bc0a55a"
-
-	// zap.S().Infow("Group", "demoUsersGroup", demoUsersGroup)
-
-	demoUsers, err := client.GetGroupMembers(ctx, token.AccessToken, realm, demoUsersGroupId, gocloak.GetGroupsParams{})
-	if err != nil {
-		zap.S().Errorf("Could not get users of demo group (id: %v): %v", err, demoUsersGroupId)
+	if err := c.App.Controllers.Demo.CleanUpDemoUsers(context.Background(), time.Now()); err != nil {
+		zap.S().Errorf("Could not clean up demo users: %v", err)
 		return err
 	}
-	// zap.S().Infow("Users", "users", demoUsers)
-	for _, user := range demoUsers {
-		userID := user.ID
-		// zap.S().Infow("user", "userID", user.ID)
-		if err := c.App.Queries.Item.DeleteItems(*userID); err != nil {
-			zap.S().Errorf("Error deleting items: %v", err)
-			return err
-		}
-		if err := c.App.Queries.List.DeleteLists(*userID); err != nil {
-			zap.S().Errorf("Error deleting lists: %v", err)
-			return err
-		}
-	}
 
 	zap.S().Infow("Finished job", "job name", job.Name)
 	return nil